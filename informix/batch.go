@@ -0,0 +1,46 @@
+package informix
+
+import (
+	"fmt"
+	"strings"
+)
+
+// InterpolateBatch interpolates a script of several statements
+// separated by semicolons. The arguments are consumed by the
+// statements in order: each statement takes as many arguments as its
+// placeholders refer to, and its $1 refers to the first of them. The
+// number of arguments must match the script exactly.
+func InterpolateBatch(query string, args ...interface{}) (string, error) {
+	return defaultInterpolator().InterpolateBatch(query, args...)
+}
+
+// InterpolateBatch is like the package level InterpolateBatch, but
+// renders values using the settings of ip.
+func (ip *Interpolator) InterpolateBatch(query string, args ...interface{}) (string, error) {
+	var b strings.Builder
+	used := 0
+	stmts, err := splitStatements(query)
+	if err != nil {
+		return "", err
+	}
+	for i, stmt := range stmts {
+		phs, err := ip.scanPlaceholders(stmt)
+		if err != nil {
+			return "", err
+		}
+		n := assignIndexes(phs, ip.dollarBase())
+		if used+n > len(args) {
+			return "", fmt.Errorf("%w: statement %d needs %d arguments, %d left", ErrTooFewArgs, i+1, n, len(args)-used)
+		}
+		s, err := ip.InterpolateQuery(stmt, args[used:used+n]...)
+		if err != nil {
+			return "", fmt.Errorf("statement %d: %w", i+1, err)
+		}
+		b.WriteString(s)
+		used += n
+	}
+	if used < len(args) {
+		return "", fmt.Errorf("%w: expected %d, got %d", ErrTooManyArgs, used, len(args))
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,72 @@
+package informix
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestInterpolateBatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		args     []interface{}
+		expected string
+		wantErr  error
+	}{
+		{
+			name:     "two statements",
+			query:    "INSERT INTO t (a, b) VALUES ($1, $2); UPDATE u SET c = $1 WHERE d = $2;",
+			args:     []interface{}{1, "x", 2, "y"},
+			expected: "INSERT INTO t (a, b) VALUES (1, 'x'); UPDATE u SET c = 2 WHERE d = 'y';",
+		},
+		{
+			name:     "question marks",
+			query:    "DELETE FROM t WHERE a = ?; DELETE FROM u WHERE b = ? AND c = ?",
+			args:     []interface{}{1, 2, 3},
+			expected: "DELETE FROM t WHERE a = 1; DELETE FROM u WHERE b = 2 AND c = 3",
+		},
+		{
+			name:     "reused placeholder",
+			query:    "UPDATE t SET a = $1 WHERE b = $1; DELETE FROM u WHERE c = $1",
+			args:     []interface{}{1, 2},
+			expected: "UPDATE t SET a = 1 WHERE b = 1; DELETE FROM u WHERE c = 2",
+		},
+		{
+			name:     "semicolons in literal and comment",
+			query:    "INSERT INTO t VALUES ('a;b', $1); -- c; $9\nDELETE FROM t WHERE a = $1 /* ; */;",
+			args:     []interface{}{1, 2},
+			expected: "INSERT INTO t VALUES ('a;b', 1); -- c; $9\nDELETE FROM t WHERE a = 2 /* ; */;",
+		},
+		{
+			name:     "statement without placeholders",
+			query:    "SET LOCK MODE TO WAIT; SELECT * FROM t WHERE a = $1",
+			args:     []interface{}{1},
+			expected: "SET LOCK MODE TO WAIT; SELECT * FROM t WHERE a = 1",
+		},
+		{
+			name:    "too few",
+			query:   "DELETE FROM t WHERE a = $1; DELETE FROM u WHERE b = $1",
+			args:    []interface{}{1},
+			wantErr: ErrTooFewArgs,
+		},
+		{
+			name:    "too many",
+			query:   "DELETE FROM t WHERE a = $1; DELETE FROM u WHERE b = $1",
+			args:    []interface{}{1, 2, 3},
+			wantErr: ErrTooManyArgs,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := InterpolateBatch(tt.query, tt.args...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("InterpolateBatch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.expected {
+				t.Errorf("InterpolateBatch() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package informix
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// BuildInsert returns an INSERT statement for the struct row, and its
+// arguments in placeholder order, for use with InterpolateQuery or
+// Parameterize. Columns are taken from the exported fields of row, in
+// declaration order, including those of embedded structs. The column
+// name is given by a db:"name" tag, or is the lower case field name.
+// Fields tagged db:"-" are skipped, and so are zero fields tagged
+// db:"name,omitempty", so that the column gets its default. A nil
+// pointer field is inserted as NULL.
+//
+// table and the column names are quoted with QuoteIdentifier; a
+// qualified table name such as owner.orders is quoted part by part.
+func BuildInsert(table string, row interface{}) (query string, args []interface{}, err error) {
+	cols, args, err := structColumns(row, false)
+	if err != nil {
+		return "", nil, err
+	}
+	name, err := quoteQualified(table)
+	if err != nil {
+		return "", nil, err
+	}
+	list, err := QuoteIdentifiers(cols...)
+	if err != nil {
+		return "", nil, err
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", name, list, dollarList(1, len(args))), args, nil
+}
+
+// BuildMultiInsert returns an INSERT statement that inserts rows into
+// the given columns of table with one VALUES list, as in
+// INSERT INTO "t" ("a", "b") VALUES (1, 'x'), (2, NULL). Unlike
+// BuildInsert, the values are formatted into the statement, with the
+// default settings. Every row must have one value per column. table
+// and the column names are quoted as described for BuildInsert.
+func BuildMultiInsert(table string, columns []string, rows [][]interface{}) (query string, err error) {
+	return defaultInterpolator().BuildMultiInsert(table, columns, rows)
+}
+
+// BuildMultiInsert is like the package level BuildMultiInsert, but
+// formats values using the settings of ip.
+func (ip *Interpolator) BuildMultiInsert(table string, columns []string, rows [][]interface{}) (query string, err error) {
+	if len(rows) == 0 {
+		return "", fmt.Errorf("no rows to insert")
+	}
+	name, err := quoteQualified(table)
+	if err != nil {
+		return "", err
+	}
+	list, err := QuoteIdentifiers(columns...)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "INSERT INTO %s (%s) VALUES ", name, list)
+	for i, row := range rows {
+		if len(row) != len(columns) {
+			return "", fmt.Errorf("row %d has %d values, want %d", i, len(row), len(columns))
+		}
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteByte('(')
+		for j, v := range row {
+			s, err := ip.formatArgument(v)
+			if err != nil {
+				return "", fmt.Errorf("row %d, column %s: %w", i, columns[j], err)
+			}
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(s)
+		}
+		b.WriteByte(')')
+		if err := ip.checkSize(b.Len()); err != nil {
+			return "", err
+		}
+	}
+	return b.String(), nil
+}
+
+// BuildUpdateSet returns the SET clause of an UPDATE statement for the
+// struct row, such as "name" = $1, "email" = $2, and its arguments in
+// placeholder order. Columns are found as described for BuildInsert.
+// Only the named columns are set if cols is not empty, including zero
+// fields tagged omitempty; an unknown column is an error. Placeholders
+// for the rest of the statement start at len(args)+1.
+func BuildUpdateSet(row interface{}, cols ...string) (setClause string, args []interface{}, err error) {
+	names, vals, err := structColumns(row, len(cols) > 0)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(cols) > 0 {
+		byName := make(map[string]interface{}, len(names))
+		for i, name := range names {
+			byName[name] = vals[i]
+		}
+		names, vals = cols, make([]interface{}, len(cols))
+		for i, c := range cols {
+			v, ok := byName[c]
+			if !ok {
+				return "", nil, fmt.Errorf("%T has no column %q", row, c)
+			}
+			vals[i] = v
+		}
+	}
+
+	var b strings.Builder
+	for i, name := range names {
+		q, err := QuoteIdentifier(name)
+		if err != nil {
+			return "", nil, err
+		}
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%s = $%d", q, i+1)
+	}
+	return b.String(), vals, nil
+}
+
+// structColumns returns the column names and values of the struct row,
+// as described for BuildInsert. Zero fields tagged omitempty are kept
+// if keepEmpty is set.
+func structColumns(row interface{}, keepEmpty bool) ([]string, []interface{}, error) {
+	rv := reflect.ValueOf(row)
+	if !rv.IsValid() {
+		return nil, nil, fmt.Errorf("nil row")
+	}
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, nil, fmt.Errorf("nil %s", rv.Type())
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("%w: %T is not a struct", ErrUnsupportedType, row)
+	}
+
+	var cols []string
+	var vals []interface{}
+	for _, f := range reflect.VisibleFields(rv.Type()) {
+		if !f.IsExported() || f.Anonymous && indirect(f.Type).Kind() == reflect.Struct {
+			continue
+		}
+		tag := f.Tag.Get("db")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		v, err := rv.FieldByIndexErr(f.Index)
+		if err != nil {
+			// The field is promoted through a nil embedded pointer
+			cols = append(cols, name)
+			vals = append(vals, nil)
+			continue
+		}
+		if opts == "omitempty" && !keepEmpty && v.IsZero() {
+			continue
+		}
+		cols = append(cols, name)
+		vals = append(vals, v.Interface())
+	}
+	if len(cols) == 0 {
+		return nil, nil, fmt.Errorf("%s has no columns", rv.Type())
+	}
+	return cols, vals, nil
+}
+
+// indirect returns the type t points to, or t if it is not a pointer.
+func indirect(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+	return t
+}
+
+// quoteQualified quotes every dot separated part of name with
+// QuoteIdentifier.
+func quoteQualified(name string) (string, error) {
+	parts := strings.Split(name, ".")
+	for i, p := range parts {
+		q, err := QuoteIdentifier(p)
+		if err != nil {
+			return "", err
+		}
+		parts[i] = q
+	}
+	return strings.Join(parts, "."), nil
+}
+
+// dollarList returns n comma separated $n placeholders, numbered from
+// first.
+func dollarList(first, n int) string {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "$%d", first+i)
+	}
+	return b.String()
+}
@@ -0,0 +1,246 @@
+package informix
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildInsert(t *testing.T) {
+	type audit struct {
+		CreatedBy string `db:"created_by"`
+	}
+	type tagged struct {
+		ID    int     `db:"id,omitempty"`
+		Name  string  `db:"full_name"`
+		Email *string `db:"email"`
+		Notes string  `db:"-"`
+		cache string
+		audit
+	}
+	type untagged struct {
+		ID   int
+		Name string
+	}
+	email := "a@example.com"
+
+	tests := []struct {
+		name          string
+		table         string
+		row           interface{}
+		expectedQuery string
+		expectedArgs  []interface{}
+		wantErr       bool
+	}{
+		{
+			name:          "tags",
+			table:         "users",
+			row:           tagged{ID: 7, Name: "Ann", Email: &email, Notes: "x", audit: audit{CreatedBy: "bob"}},
+			expectedQuery: `INSERT INTO "users" ("id", "full_name", "email", "created_by") VALUES ($1, $2, $3, $4)`,
+			expectedArgs:  []interface{}{7, "Ann", &email, "bob"},
+		},
+		{
+			name:          "omitted zero field and nil pointer",
+			table:         "owner.users",
+			row:           &tagged{Name: "Ann"},
+			expectedQuery: `INSERT INTO "owner"."users" ("full_name", "email", "created_by") VALUES ($1, $2, $3)`,
+			expectedArgs:  []interface{}{"Ann", (*string)(nil), ""},
+		},
+		{
+			name:          "untagged",
+			table:         "users",
+			row:           untagged{ID: 1, Name: "it's"},
+			expectedQuery: `INSERT INTO "users" ("id", "name") VALUES ($1, $2)`,
+			expectedArgs:  []interface{}{1, "it's"},
+		},
+		{
+			name:    "not a struct",
+			table:   "users",
+			row:     map[string]interface{}{"id": 1},
+			wantErr: true,
+		},
+		{
+			name:    "no columns",
+			table:   "users",
+			row:     struct{ a int }{},
+			wantErr: true,
+		},
+		{
+			name:    "empty table",
+			table:   "",
+			row:     untagged{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := BuildInsert(tt.table, tt.row)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BuildInsert() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if query != tt.expectedQuery {
+				t.Errorf("BuildInsert() query = %v, want %v", query, tt.expectedQuery)
+			}
+			if !reflect.DeepEqual(args, tt.expectedArgs) {
+				t.Errorf("BuildInsert() args = %v, want %v", args, tt.expectedArgs)
+			}
+		})
+	}
+
+	query, args, err := BuildInsert("users", tagged{Name: "Ann", Email: &email})
+	if err != nil {
+		t.Fatalf("BuildInsert() error = %v", err)
+	}
+	got, err := InterpolateQuery(query, args...)
+	if err != nil {
+		t.Fatalf("InterpolateQuery() error = %v", err)
+	}
+	if want := `INSERT INTO "users" ("full_name", "email", "created_by") VALUES ('Ann', 'a@example.com', '')`; got != want {
+		t.Errorf("InterpolateQuery() = %v, want %v", got, want)
+	}
+}
+
+func TestBuildUpdateSet(t *testing.T) {
+	type user struct {
+		ID    int    `db:"id,omitempty"`
+		Name  string `db:"full_name"`
+		Email string
+		Notes string `db:"-"`
+	}
+	row := user{Name: "Ann", Email: "a@example.com", Notes: "x"}
+
+	tests := []struct {
+		name         string
+		row          interface{}
+		cols         []string
+		expectedSet  string
+		expectedArgs []interface{}
+		wantErr      bool
+	}{
+		{
+			name:         "all columns",
+			row:          row,
+			expectedSet:  `"full_name" = $1, "email" = $2`,
+			expectedArgs: []interface{}{"Ann", "a@example.com"},
+		},
+		{
+			name:         "subset",
+			row:          &row,
+			cols:         []string{"email"},
+			expectedSet:  `"email" = $1`,
+			expectedArgs: []interface{}{"a@example.com"},
+		},
+		{
+			name:         "subset with omitted zero field",
+			row:          row,
+			cols:         []string{"id", "full_name"},
+			expectedSet:  `"id" = $1, "full_name" = $2`,
+			expectedArgs: []interface{}{0, "Ann"},
+		},
+		{
+			name:    "unknown column",
+			row:     row,
+			cols:    []string{"notes"},
+			wantErr: true,
+		},
+		{
+			name:    "nil",
+			row:     nil,
+			wantErr: true,
+		},
+		{
+			name:    "nil pointer",
+			row:     (*user)(nil),
+			wantErr: true,
+		},
+		{
+			name:    "not a struct",
+			row:     "Ann",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set, args, err := BuildUpdateSet(tt.row, tt.cols...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BuildUpdateSet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if set != tt.expectedSet {
+				t.Errorf("BuildUpdateSet() set = %v, want %v", set, tt.expectedSet)
+			}
+			if !reflect.DeepEqual(args, tt.expectedArgs) {
+				t.Errorf("BuildUpdateSet() args = %v, want %v", args, tt.expectedArgs)
+			}
+		})
+	}
+}
+
+func TestBuildMultiInsert(t *testing.T) {
+	columns := []string{"id", "active", "note"}
+
+	tests := []struct {
+		name     string
+		dialect  Dialect
+		table    string
+		columns  []string
+		rows     [][]interface{}
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "postgres",
+			table:    "flags",
+			columns:  columns,
+			rows:     [][]interface{}{{1, true, "it's"}, {2, false, nil}},
+			expected: `INSERT INTO "flags" ("id", "active", "note") VALUES (1, true, 'it''s'), (2, false, NULL)`,
+		},
+		{
+			name:     "informix",
+			dialect:  Informix,
+			table:    "owner.flags",
+			columns:  columns,
+			rows:     [][]interface{}{{1, true, nil}, {2, false, "x"}},
+			expected: `INSERT INTO "owner"."flags" ("id", "active", "note") VALUES (1, 't', NULL), (2, 'f', 'x')`,
+		},
+		{
+			name:    "short row",
+			table:   "flags",
+			columns: columns,
+			rows:    [][]interface{}{{1, true, nil}, {2, false}},
+			wantErr: true,
+		},
+		{
+			name:    "no rows",
+			table:   "flags",
+			columns: columns,
+			wantErr: true,
+		},
+		{
+			name:    "no columns",
+			table:   "flags",
+			rows:    [][]interface{}{{}},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported value",
+			table:   "flags",
+			columns: columns,
+			rows:    [][]interface{}{{1, true, complex(1, 2)}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip := &Interpolator{Dialect: tt.dialect}
+			got, err := ip.BuildMultiInsert(tt.table, tt.columns, tt.rows)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BuildMultiInsert() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("BuildMultiInsert() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package informix
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Builder assembles an interpolated query from fragments. Placeholders
+// are numbered across the whole query rather than per fragment: the
+// arguments of each WriteQuery call continue the argument list of the
+// calls before it, so the first fragment might use $1 and $2 and the
+// second $3. A $n placeholder may also refer back to the argument of
+// an earlier fragment. Each ? takes the next new argument.
+//
+// The zero value is ready to use and renders values with the default
+// settings.
+type Builder struct {
+	// Interpolator holds the settings used to render values. If nil,
+	// the default settings are used.
+	Interpolator *Interpolator
+
+	b    strings.Builder
+	args []interface{}
+}
+
+// WriteQuery interpolates query and appends it to the query built so
+// far. Every placeholder must have an argument, whatever the
+// StrictArgs option, and every argument in args must be referred to by
+// query. If an error is returned, nothing is appended and args are
+// discarded.
+func (qb *Builder) WriteQuery(query string, args ...interface{}) error {
+	ip := qb.Interpolator
+	if ip == nil {
+		ip = defaultInterpolator()
+	}
+	phs, err := ip.scanPlaceholders(query)
+	if err != nil {
+		return err
+	}
+	if !ip.AllowMixedStyles {
+		if err := checkMixedStyles(phs); err != nil {
+			return err
+		}
+	}
+	assignIndexes(phs, ip.dollarBase())
+	first := len(qb.args)
+	all := append(qb.args[:first:first], args...)
+	for i := range phs {
+		if phs[i].kind == questionMark {
+			phs[i].index += first
+		}
+	}
+
+	s, err := replacePlaceholders(query, phs, func(ph placeholder) (string, error) {
+		if ph.index < 0 || ph.index >= len(all) {
+			return "", fmt.Errorf("%w: placeholder %s has no argument, got %d", ErrTooFewArgs, query[ph.start:ph.end], len(all))
+		}
+		s, err := ip.formatArgument(all[ph.index])
+		if err != nil {
+			return "", fmt.Errorf("argument %d: %w", ph.index+1, err)
+		}
+		return s, nil
+	})
+	if err != nil {
+		return err
+	}
+	referenced := make([]bool, len(args))
+	for _, ph := range phs {
+		if ph.index >= first {
+			referenced[ph.index-first] = true
+		}
+	}
+	for i, ok := range referenced {
+		if !ok {
+			return fmt.Errorf("%w: argument %d is not used, got %d", ErrTooManyArgs, first+i+1, len(all))
+		}
+	}
+	if err := ip.checkSize(qb.b.Len() + len(s)); err != nil {
+		return err
+	}
+
+	qb.b.WriteString(s)
+	qb.args = all
+	return nil
+}
+
+// String returns the query built so far.
+func (qb *Builder) String() string {
+	return qb.b.String()
+}
+
+// NumArgs returns the number of arguments written so far, so that the
+// next fragment can number its placeholders from NumArgs()+1.
+func (qb *Builder) NumArgs() int {
+	return len(qb.args)
+}
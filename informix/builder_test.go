@@ -0,0 +1,90 @@
+package informix
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBuilder(t *testing.T) {
+	var qb Builder
+	if err := qb.WriteQuery("SELECT * FROM users WHERE name = $1", "O'Brien"); err != nil {
+		t.Fatalf("WriteQuery() error = %v", err)
+	}
+	if err := qb.WriteQuery(" AND age BETWEEN $2 AND $3", 18, 65); err != nil {
+		t.Fatalf("WriteQuery() error = %v", err)
+	}
+	if err := qb.WriteQuery(" AND (nick = $1 OR id IN $4)", []int{1, 2}); err != nil {
+		t.Fatalf("WriteQuery() error = %v", err)
+	}
+	expected := "SELECT * FROM users WHERE name = 'O''Brien' AND age BETWEEN 18 AND 65 AND (nick = 'O''Brien' OR id IN (1,2))"
+	if got := qb.String(); got != expected {
+		t.Errorf("String() = %v, want %v", got, expected)
+	}
+	if got := qb.NumArgs(); got != 4 {
+		t.Errorf("NumArgs() = %d, want 4", got)
+	}
+}
+
+func TestBuilderQuestionMarks(t *testing.T) {
+	qb := Builder{Interpolator: &Interpolator{Dialect: Informix}}
+	fragments := []struct {
+		query string
+		args  []interface{}
+	}{
+		{"UPDATE t SET a = ?", []interface{}{true}},
+		{", b = ?", []interface{}{"x"}},
+		{" WHERE id = ? AND c = ?", []interface{}{7, nil}},
+	}
+	for _, f := range fragments {
+		if err := qb.WriteQuery(f.query, f.args...); err != nil {
+			t.Fatalf("WriteQuery(%q) error = %v", f.query, err)
+		}
+	}
+	if expected := "UPDATE t SET a = 't', b = 'x' WHERE id = 7 AND c = NULL"; qb.String() != expected {
+		t.Errorf("String() = %v, want %v", qb.String(), expected)
+	}
+}
+
+func TestBuilderErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		args    []interface{}
+		wantErr error
+	}{
+		{
+			name:    "refers past the arguments",
+			query:   " AND b = $3",
+			args:    []interface{}{2},
+			wantErr: ErrTooFewArgs,
+		},
+		{
+			name:    "new argument not used",
+			query:   " AND b = $1",
+			args:    []interface{}{2},
+			wantErr: ErrTooManyArgs,
+		},
+		{
+			name:    "unterminated",
+			query:   " AND b = 'x",
+			wantErr: ErrUnterminated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var qb Builder
+			if err := qb.WriteQuery("SELECT * FROM t WHERE a = $1", 1); err != nil {
+				t.Fatalf("WriteQuery() error = %v", err)
+			}
+			err := qb.WriteQuery(tt.query, tt.args...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("WriteQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			// A failed fragment leaves the builder unchanged.
+			if expected := "SELECT * FROM t WHERE a = 1"; qb.String() != expected || qb.NumArgs() != 1 {
+				t.Errorf("String() = %v with %d arguments, want %v with 1", qb.String(), qb.NumArgs(), expected)
+			}
+		})
+	}
+}
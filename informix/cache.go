@@ -0,0 +1,124 @@
+package informix
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+)
+
+// scanKey identifies the result of scanning a query.
+type scanKey struct {
+	query      string
+	style      Style
+	namePrefix byte
+}
+
+// scanCache is a least recently used cache of scanned queries.
+type scanCache struct {
+	// on mirrors size != 0, so that callers can skip a disabled cache
+	// without taking mu
+	on atomic.Bool
+
+	mu    sync.Mutex
+	size  int
+	order *list.List // of *scanEntry, most recently used first
+	items map[scanKey]*list.Element
+}
+
+type scanEntry struct {
+	key scanKey
+	phs []placeholder
+}
+
+// queryCache caches the placeholders of queries passed to the
+// interpolation functions. It is disabled until SetQueryCacheSize is
+// called.
+var queryCache scanCache
+
+// SetQueryCacheSize enables caching of the parsed form of up to n
+// queries, so that repeated calls with the same query text do not
+// parse it again. The least recently used query is dropped when the
+// cache is full. A size of 0, the default, disables the cache and
+// frees its contents.
+func SetQueryCacheSize(n int) {
+	queryCache.resize(n)
+}
+
+func (c *scanCache) resize(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if n <= 0 {
+		c.size, c.order, c.items = 0, nil, nil
+		c.on.Store(false)
+		return
+	}
+	c.size = n
+	c.on.Store(true)
+	if c.order == nil {
+		c.order = list.New()
+		c.items = make(map[scanKey]*list.Element)
+	}
+	c.trim()
+}
+
+// enabled reports whether c has a non-zero size. It does not lock c,
+// so get and add must still check the size, which may have changed.
+func (c *scanCache) enabled() bool {
+	return c.on.Load()
+}
+
+// trim drops least recently used entries until c fits its size.
+func (c *scanCache) trim() {
+	for c.order.Len() > c.size {
+		e := c.order.Back()
+		c.order.Remove(e)
+		delete(c.items, e.Value.(*scanEntry).key)
+	}
+}
+
+// get returns a copy of the placeholders cached for key.
+func (c *scanCache) get(key scanKey) ([]placeholder, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.size == 0 {
+		return nil, false
+	}
+	e, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	return clonePlaceholders(e.Value.(*scanEntry).phs), true
+}
+
+// add caches a copy of phs for key.
+func (c *scanCache) add(key scanKey, phs []placeholder) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.size == 0 {
+		return
+	}
+	if e, ok := c.items[key]; ok {
+		c.order.MoveToFront(e)
+		return
+	}
+	c.items[key] = c.order.PushFront(&scanEntry{key: key, phs: clonePlaceholders(phs)})
+	c.trim()
+}
+
+// len returns the number of cached queries.
+func (c *scanCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.order == nil {
+		return 0
+	}
+	return c.order.Len()
+}
+
+func clonePlaceholders(phs []placeholder) []placeholder {
+	if phs == nil {
+		return nil
+	}
+	return append([]placeholder(nil), phs...)
+}
@@ -0,0 +1,94 @@
+package informix
+
+import "testing"
+
+func TestQueryCache(t *testing.T) {
+	SetQueryCacheSize(2)
+	defer SetQueryCacheSize(0)
+
+	queries := []struct {
+		query    string
+		args     []interface{}
+		expected string
+	}{
+		{
+			query:    "SELECT * FROM t WHERE a = $2 AND b = $1",
+			args:     []interface{}{1, "x"},
+			expected: "SELECT * FROM t WHERE a = 'x' AND b = 1",
+		},
+		{
+			query:    "SELECT * FROM t WHERE a = ? AND b = ?",
+			args:     []interface{}{1, "x"},
+			expected: "SELECT * FROM t WHERE a = 1 AND b = 'x'",
+		},
+		{
+			query:    "SELECT '?' FROM t WHERE a = ?",
+			args:     []interface{}{2},
+			expected: "SELECT '?' FROM t WHERE a = 2",
+		},
+	}
+
+	// Run every query several times, so that later runs use cached
+	// and evicted entries.
+	for i := 0; i < 3; i++ {
+		for _, q := range queries {
+			got, err := InterpolateQuery(q.query, q.args...)
+			if err != nil {
+				t.Fatalf("InterpolateQuery(%q) error = %v", q.query, err)
+			}
+			if got != q.expected {
+				t.Errorf("InterpolateQuery() = %v, want %v", got, q.expected)
+			}
+		}
+	}
+	if n := queryCache.len(); n != 2 {
+		t.Errorf("cache holds %d queries, want 2", n)
+	}
+
+	// Named and positional scans of the same text are cached apart.
+	query := "SELECT :a, ?"
+	if got, _ := InterpolateQuery(query, 1); got != "SELECT :a, 1" {
+		t.Errorf("InterpolateQuery() = %v", got)
+	}
+	if got, _ := InterpolateNamed(query, map[string]interface{}{"a": 2}); got != "SELECT 2, ?" {
+		t.Errorf("InterpolateNamed() = %v", got)
+	}
+
+	SetQueryCacheSize(0)
+	if n := queryCache.len(); n != 0 {
+		t.Errorf("disabled cache holds %d queries, want 0", n)
+	}
+	if queryCache.enabled() {
+		t.Errorf("disabled cache reports itself enabled")
+	}
+	if _, err := InterpolateQuery(queries[0].query, queries[0].args...); err != nil {
+		t.Fatalf("InterpolateQuery() error = %v", err)
+	}
+	if n := queryCache.len(); n != 0 {
+		t.Errorf("disabled cache holds %d queries after a query, want 0", n)
+	}
+}
+
+func benchmarkRepeatedQuery(b *testing.B, cacheSize int) {
+	SetQueryCacheSize(cacheSize)
+	defer SetQueryCacheSize(0)
+
+	query := "SELECT id, name, email FROM users /* lookup */ WHERE id = $1 AND name = $2 AND email LIKE '%@example.com' AND active = $3 -- hot path"
+	args := []interface{}{123, "John", true}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := InterpolateQuery(query, args...)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRepeatedQueryUncached(b *testing.B) {
+	benchmarkRepeatedQuery(b, 0)
+}
+
+func BenchmarkRepeatedQueryCached(b *testing.B) {
+	benchmarkRepeatedQuery(b, 100)
+}
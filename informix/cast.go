@@ -0,0 +1,126 @@
+package informix
+
+import "fmt"
+
+// CastValue is an argument rendered as CAST(value AS type). It is made
+// by Cast.
+type CastValue struct {
+	Value interface{}
+	Type  string
+}
+
+// Cast returns an argument that is rendered as CAST(value AS sqlType),
+// for literals whose type the server cannot infer, such as a string
+// going into a DATETIME or LVARCHAR column. value is formatted like
+// any other argument. sqlType is written verbatim and must be one or
+// more words, each optionally followed by a numeric precision, as in
+// DECIMAL(10,2) or DATETIME YEAR TO SECOND.
+func Cast(value interface{}, sqlType string) CastValue {
+	return CastValue{Value: value, Type: sqlType}
+}
+
+// formatCast returns c as a CAST expression. The formatting of its
+// value is recorded in tr.
+func (ip *Interpolator) formatCast(c CastValue, tr *argTrace) (string, error) {
+	if err := checkSQLType(c.Type); err != nil {
+		return "", err
+	}
+	s, err := ip.format(c.Value, tr)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("CAST(%s AS %s)", s, c.Type), nil
+}
+
+// checkSQLType returns an error unless t is a SQL type name: one or
+// more words separated by spaces, each optionally followed by a
+// precision such as (10) or (10,2). This covers DECIMAL(10,2),
+// DOUBLE PRECISION and DATETIME YEAR TO FRACTION(3), and leaves no way
+// to close the CAST early or smuggle in other SQL.
+func checkSQLType(t string) error {
+	if t == "" {
+		return fmt.Errorf("empty cast type")
+	}
+	i := 0
+	for {
+		n := scanTypeWord(t[i:])
+		if n == 0 {
+			return fmt.Errorf("invalid cast type %q", t)
+		}
+		i += n
+		sp := scanSpaces(t[i:])
+		if i+sp < len(t) && t[i+sp] == '(' {
+			n := scanTypePrecision(t[i+sp:])
+			if n == 0 {
+				return fmt.Errorf("invalid precision in cast type %q", t)
+			}
+			i += sp + n
+			sp = scanSpaces(t[i:])
+		}
+		i += sp
+		if i == len(t) {
+			return nil
+		}
+		if sp == 0 {
+			return fmt.Errorf("invalid cast type %q", t)
+		}
+	}
+}
+
+// scanTypeWord returns the length of the word, possibly qualified with
+// dots as in schema.type, at the start of s, or 0 if there is none.
+func scanTypeWord(s string) int {
+	i := 0
+	for {
+		if i >= len(s) || !(isLetter(s[i]) || s[i] == '_') {
+			return 0
+		}
+		i++
+		for i < len(s) && (isLetter(s[i]) || isDigit(s[i]) || s[i] == '_') {
+			i++
+		}
+		if i+1 >= len(s) || s[i] != '.' {
+			return i
+		}
+		i++
+	}
+}
+
+// scanTypePrecision returns the length of the (digits[,digits])
+// precision at the start of s, or 0 if there is none.
+func scanTypePrecision(s string) int {
+	i := 1
+	for part := 0; part < 2; part++ {
+		i += scanSpaces(s[i:])
+		n := 0
+		for i < len(s) && isDigit(s[i]) {
+			i++
+			n++
+		}
+		if n == 0 {
+			return 0
+		}
+		i += scanSpaces(s[i:])
+		if i < len(s) && s[i] == ')' {
+			return i + 1
+		}
+		if i >= len(s) || s[i] != ',' {
+			return 0
+		}
+		i++
+	}
+	return 0
+}
+
+// scanSpaces returns the number of spaces at the start of s.
+func scanSpaces(s string) int {
+	i := 0
+	for i < len(s) && s[i] == ' ' {
+		i++
+	}
+	return i
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
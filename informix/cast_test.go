@@ -0,0 +1,131 @@
+package informix
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCast(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  Dialect
+		arg      interface{}
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "string",
+			arg:      Cast("2024-01-02 03:04:05", "DATETIME YEAR TO SECOND"),
+			expected: "CAST('2024-01-02 03:04:05' AS DATETIME YEAR TO SECOND)",
+		},
+		{
+			name:     "escaped string",
+			arg:      Cast("it's", "LVARCHAR"),
+			expected: "CAST('it''s' AS LVARCHAR)",
+		},
+		{
+			name:     "number with precision",
+			arg:      Cast(12.5, "DECIMAL(10,2)"),
+			expected: "CAST(12.500000 AS DECIMAL(10,2))",
+		},
+		{
+			name:     "nil",
+			arg:      Cast(nil, "INTEGER"),
+			expected: "CAST(NULL AS INTEGER)",
+		},
+		{
+			name:     "time in informix",
+			dialect:  Informix,
+			arg:      Cast(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), "DATE"),
+			expected: "CAST('2024-01-02 00:00:00' AS DATE)",
+		},
+		{
+			name:     "nested",
+			arg:      Cast(Cast(1, "SMALLINT"), "INTEGER"),
+			expected: "CAST(CAST(1 AS SMALLINT) AS INTEGER)",
+		},
+		{
+			name:     "in a list",
+			arg:      []interface{}{Cast("a", "CHAR(1)")},
+			expected: "ARRAY[CAST('a' AS CHAR(1))]",
+		},
+		{
+			name:    "quote in type",
+			arg:     Cast(1, "INTEGER) || '"),
+			wantErr: true,
+		},
+		{
+			name:    "semicolon in type",
+			arg:     Cast(1, "INTEGER); DROP TABLE t"),
+			wantErr: true,
+		},
+		{
+			name:    "comment in type",
+			arg:     Cast(1, "INTEGER--"),
+			wantErr: true,
+		},
+		{
+			name:     "precision with spaces",
+			arg:      Cast(1, "DECIMAL ( 10, 2 )"),
+			expected: "CAST(1 AS DECIMAL ( 10, 2 ))",
+		},
+		{
+			name:     "qualified fraction",
+			arg:      Cast("12:00:00.123", "DATETIME HOUR TO FRACTION(3)"),
+			expected: "CAST('12:00:00.123' AS DATETIME HOUR TO FRACTION(3))",
+		},
+		{
+			name:     "schema qualified",
+			arg:      Cast("a", "myschema.mytype"),
+			expected: "CAST('a' AS myschema.mytype)",
+		},
+		{
+			name:    "closing parenthesis",
+			arg:     Cast("x", "INT) UNION SELECT password FROM users WHERE (1"),
+			wantErr: true,
+		},
+		{
+			name:    "unbalanced parenthesis",
+			arg:     Cast(1, "DECIMAL(10,2"),
+			wantErr: true,
+		},
+		{
+			name:    "word in precision",
+			arg:     Cast(1, "CHAR(SELECT 1)"),
+			wantErr: true,
+		},
+		{
+			name:    "nested parentheses",
+			arg:     Cast(1, "CHAR((1))"),
+			wantErr: true,
+		},
+		{
+			name:    "trailing dot",
+			arg:     Cast(1, "INTEGER."),
+			wantErr: true,
+		},
+		{
+			name:    "precision without space",
+			arg:     Cast(1, "CHAR(1)x"),
+			wantErr: true,
+		},
+		{
+			name:    "empty type",
+			arg:     Cast(1, ""),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip := &Interpolator{Dialect: tt.dialect}
+			got, err := ip.formatArgument(tt.arg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("formatArgument() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package informix
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DateOnly is a time.Time argument of which only the date is written,
+// as DATE '2024-02-12', or for Informix DATETIME(2024-02-12) YEAR TO
+// DAY, for DATE columns. The date is taken in the location of the
+// time.
+type DateOnly time.Time
+
+// TimeOnly is a time.Time argument of which only the time of day is
+// written, as TIME '15:04:05', or for Informix DATETIME(15:04:05) HOUR
+// TO SECOND. The time is taken in the location of the time.Time.
+type TimeOnly time.Time
+
+// formatDate returns the date of t as a date literal.
+func (ip *Interpolator) formatDate(t time.Time) string {
+	if ip.ZeroTimeAsNull && t.IsZero() {
+		return "NULL"
+	}
+	if ip.Dialect == Informix {
+		return fmt.Sprintf("DATETIME(%s) YEAR TO DAY", t.Format("2006-01-02"))
+	}
+	return fmt.Sprintf("DATE '%s'", t.Format("2006-01-02"))
+}
+
+// formatTimeOfDay returns the time of day of t as a time literal.
+func (ip *Interpolator) formatTimeOfDay(t time.Time) string {
+	if ip.ZeroTimeAsNull && t.IsZero() {
+		return "NULL"
+	}
+	t, digits, frac := ip.fraction(t)
+	switch {
+	case ip.Dialect == Informix && t.Nanosecond() != 0:
+		return fmt.Sprintf("DATETIME(%s) HOUR TO FRACTION(%d)", t.Format("15:04:05."+strings.Repeat("0", digits)), digits)
+	case ip.Dialect == Informix:
+		return fmt.Sprintf("DATETIME(%s) HOUR TO SECOND", t.Format("15:04:05"))
+	}
+	return fmt.Sprintf("TIME '%s'", t.Format("15:04:05"+frac))
+}
@@ -0,0 +1,106 @@
+package informix
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateOnlyTimeOnly(t *testing.T) {
+	tm := time.Date(2024, 2, 12, 15, 4, 5, 0, time.UTC)
+	frac := time.Date(2024, 2, 12, 15, 4, 5, 250000000, time.UTC)
+
+	tests := []struct {
+		name     string
+		ip       Interpolator
+		arg      interface{}
+		expected string
+	}{
+		{
+			name:     "date",
+			arg:      DateOnly(tm),
+			expected: "DATE '2024-02-12'",
+		},
+		{
+			name:     "informix date",
+			ip:       Interpolator{Dialect: Informix},
+			arg:      DateOnly(tm),
+			expected: "DATETIME(2024-02-12) YEAR TO DAY",
+		},
+		{
+			name:     "date in own location",
+			ip:       Interpolator{Location: time.UTC},
+			arg:      DateOnly(time.Date(2024, 2, 12, 23, 0, 0, 0, time.FixedZone("UTC-5", -5*60*60))),
+			expected: "DATE '2024-02-12'",
+		},
+		{
+			name:     "zero date as null",
+			ip:       Interpolator{ZeroTimeAsNull: true},
+			arg:      DateOnly{},
+			expected: "NULL",
+		},
+		{
+			name:     "time",
+			arg:      TimeOnly(tm),
+			expected: "TIME '15:04:05'",
+		},
+		{
+			name:     "time with fraction",
+			arg:      TimeOnly(frac),
+			expected: "TIME '15:04:05.25'",
+		},
+		{
+			name:     "fixed fraction time",
+			ip:       Interpolator{FixedFraction: true},
+			arg:      TimeOnly(frac),
+			expected: "TIME '15:04:05.250000'",
+		},
+		{
+			name:     "informix time",
+			ip:       Interpolator{Dialect: Informix},
+			arg:      TimeOnly(tm),
+			expected: "DATETIME(15:04:05) HOUR TO SECOND",
+		},
+		{
+			name:     "informix time with fraction",
+			ip:       Interpolator{Dialect: Informix},
+			arg:      TimeOnly(frac),
+			expected: "DATETIME(15:04:05.25000) HOUR TO FRACTION(5)",
+		},
+		{
+			name:     "time without fraction",
+			ip:       Interpolator{FractionDigits: NoFraction},
+			arg:      TimeOnly(frac),
+			expected: "TIME '15:04:05'",
+		},
+		{
+			name:     "time with two digits",
+			ip:       Interpolator{FractionDigits: 2, FixedFraction: true},
+			arg:      TimeOnly(time.Date(2024, 2, 12, 15, 4, 5, 123456789, time.UTC)),
+			expected: "TIME '15:04:05.12'",
+		},
+		{
+			name:     "informix time without fraction",
+			ip:       Interpolator{Dialect: Informix, FractionDigits: NoFraction},
+			arg:      TimeOnly(frac),
+			expected: "DATETIME(15:04:05) HOUR TO SECOND",
+		},
+		{
+			name:     "informix time with three digits",
+			ip:       Interpolator{Dialect: Informix, FractionDigits: 3},
+			arg:      TimeOnly(frac),
+			expected: "DATETIME(15:04:05.250) HOUR TO FRACTION(3)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.ip.formatArgument(tt.arg)
+			if err != nil {
+				t.Fatalf("formatArgument() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package informix
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ArgMapping describes how one placeholder of a query is interpolated.
+type ArgMapping struct {
+	// Placeholder is the placeholder token, such as $1 or ?.
+	Placeholder string
+
+	// Offset is the byte offset of the placeholder in the query.
+	Offset int
+
+	// Arg is the index of the argument the placeholder refers to, or
+	// -1 if there is no such argument.
+	Arg int
+
+	// Type is the Go type of the argument, as printed by %T.
+	Type string
+
+	// Branch names the way the argument is formatted, such as
+	// "integer", "string" or "driver.Valuer: timestamp".
+	Branch string
+
+	// Literal is the SQL the argument is formatted as, unless Err is
+	// set.
+	Literal string
+
+	// Err is the error formatting the argument, if any.
+	Err error
+}
+
+// Explain reports, for every placeholder of query, the argument it
+// refers to and how that argument is formatted, for finding out why a
+// value is rendered in an unexpected way. It formats the arguments
+// but does not build the query. Errors in the query, and arguments
+// that do not match its placeholders, are returned as InterpolateQuery
+// returns them, following the same settings; an argument that cannot
+// be formatted has its Err set instead.
+func Explain(query string, args ...interface{}) ([]ArgMapping, error) {
+	return defaultInterpolator().Explain(query, args...)
+}
+
+// Explain is like the package level Explain, but uses the settings of
+// ip.
+func (ip *Interpolator) Explain(query string, args ...interface{}) ([]ArgMapping, error) {
+	phs, err := ip.placeholders(query, len(args), false)
+	if err != nil {
+		return nil, err
+	}
+
+	mappings := make([]ArgMapping, len(phs))
+	for i, ph := range phs {
+		m := ArgMapping{Placeholder: query[ph.start:ph.end], Offset: ph.start, Arg: -1}
+		if ph.index >= 0 && ph.index < len(args) {
+			arg := args[ph.index]
+			m.Arg = ph.index
+			m.Type = fmt.Sprintf("%T", arg)
+			var tr argTrace
+			m.Literal, m.Err = ip.format(arg, &tr)
+			m.Branch = tr.String()
+		}
+		mappings[i] = m
+	}
+	return mappings, nil
+}
+
+// argKind is a way formatArgument formats an argument.
+type argKind int
+
+const (
+	kindNull argKind = iota
+	kindFormatter
+	kindEnum
+	kindSQLNull
+	kindDecimal
+	kindValuer
+	kindBool
+	kindInteger
+	kindUnsigned
+	kindFloat
+	kindString
+	kindLike
+	kindHStore
+	kindNetwork
+	kindBinary
+	kindTimestamp
+	kindDate
+	kindTimeOfDay
+	kindInterval
+	kindIdentifier
+	kindRaw
+	kindSerial
+	kindMoney
+	kindRow
+	kindTuples
+	kindCast
+	kindInline
+	kindCollection
+	kindList
+	kindJSON
+	kindNumber
+	kindUUID
+	kindTextMarshaler
+	kindBinaryMarshaler
+	kindStringer
+	kindUnsupported
+	kindNamedBool
+	kindNamedInteger
+	kindNamedUnsigned
+	kindNamedFloat
+	kindNamedString
+	kindPointer
+	kindDefault
+)
+
+var argKindNames = [...]string{
+	kindNull:            "NULL",
+	kindFormatter:       "registered formatter",
+	kindEnum:            "enum",
+	kindSQLNull:         "sql.Null",
+	kindDecimal:         "decimal",
+	kindValuer:          "driver.Valuer",
+	kindBool:            "bool",
+	kindInteger:         "integer",
+	kindUnsigned:        "unsigned integer",
+	kindFloat:           "float",
+	kindString:          "string",
+	kindLike:            "LIKE pattern",
+	kindHStore:          "hstore",
+	kindNetwork:         "network address",
+	kindBinary:          "binary",
+	kindTimestamp:       "timestamp",
+	kindDate:            "date",
+	kindTimeOfDay:       "time of day",
+	kindInterval:        "interval",
+	kindIdentifier:      "identifier",
+	kindRaw:             "raw SQL",
+	kindSerial:          "serial",
+	kindMoney:           "money",
+	kindRow:             "row",
+	kindTuples:          "tuples",
+	kindCast:            "cast",
+	kindInline:          "inline",
+	kindCollection:      "collection",
+	kindList:            "list",
+	kindJSON:            "JSON",
+	kindNumber:          "number",
+	kindUUID:            "UUID",
+	kindTextMarshaler:   "encoding.TextMarshaler",
+	kindBinaryMarshaler: "encoding.BinaryMarshaler",
+	kindStringer:        "fmt.Stringer",
+	kindUnsupported:     "unsupported",
+	kindNamedBool:       "named bool",
+	kindNamedInteger:    "named integer",
+	kindNamedUnsigned:   "named unsigned integer",
+	kindNamedFloat:      "named float",
+	kindNamedString:     "named string",
+	kindPointer:         "pointer",
+	kindDefault:         "fmt %v",
+}
+
+func (k argKind) String() string {
+	if k < 0 || int(k) >= len(argKindNames) {
+		return fmt.Sprintf("argKind(%d)", int(k))
+	}
+	return argKindNames[k]
+}
+
+// argTrace records the kinds of formatting applied to an argument,
+// outermost first, as when a pointer to a driver.Valuer returns a
+// time. A nil *argTrace records nothing.
+type argTrace struct {
+	kinds []argKind
+}
+
+// add appends k to t, if t is not nil.
+func (t *argTrace) add(k argKind) {
+	if t != nil {
+		t.kinds = append(t.kinds, k)
+	}
+}
+
+// String returns the kinds in t separated by colons, such as
+// "driver.Valuer: timestamp".
+func (t *argTrace) String() string {
+	names := make([]string, len(t.kinds))
+	for i, k := range t.kinds {
+		names[i] = k.String()
+	}
+	return strings.Join(names, ": ")
+}
@@ -0,0 +1,140 @@
+package informix
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestExplain(t *testing.T) {
+	type userID int64
+	tm := time.Date(2024, 2, 12, 15, 4, 5, 0, time.UTC)
+	valuer := valuerFunc(func() (driver.Value, error) {
+		return tm, nil
+	})
+
+	got, err := Explain("SELECT * FROM t WHERE a = $1 AND b = $2 AND c = $3 AND d = $4 AND e IN $5 AND f = $6 AND g = $7",
+		42, "x", tm, valuer, []userID{1, 2}, complex(1, 2))
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+
+	expected := []ArgMapping{
+		{Placeholder: "$1", Offset: 26, Arg: 0, Type: "int", Branch: "integer", Literal: "42"},
+		{Placeholder: "$2", Offset: 37, Arg: 1, Type: "string", Branch: "string", Literal: "'x'"},
+		{Placeholder: "$3", Offset: 48, Arg: 2, Type: "time.Time", Branch: "timestamp", Literal: "'2024-02-12 15:04:05'"},
+		{Placeholder: "$4", Offset: 59, Arg: 3, Type: "informix.valuerFunc", Branch: "driver.Valuer: timestamp", Literal: "'2024-02-12 15:04:05'"},
+		{Placeholder: "$5", Offset: 71, Arg: 4, Type: "[]informix.userID", Branch: "list", Literal: "(1,2)"},
+		{Placeholder: "$6", Offset: 82, Arg: 5, Type: "complex128", Branch: "unsupported"},
+		{Placeholder: "$7", Offset: 93, Arg: -1},
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("Explain() returned %d mappings, want %d", len(got), len(expected))
+	}
+	for i, want := range expected {
+		m := got[i]
+		var wantErr error
+		if i == 5 {
+			wantErr = ErrUnsupportedType
+		}
+		if !errors.Is(m.Err, wantErr) {
+			t.Errorf("mapping %d: Err = %v, want %v", i, m.Err, wantErr)
+		}
+		m.Err = nil
+		if m != want {
+			t.Errorf("mapping %d = %+v, want %+v", i, m, want)
+		}
+	}
+}
+
+func TestExplainBranches(t *testing.T) {
+	type status string
+	type blob []byte
+	n := 5
+
+	tests := []struct {
+		name     string
+		arg      interface{}
+		expected string
+	}{
+		{name: "nil", arg: nil, expected: "NULL"},
+		{name: "typed nil", arg: (*int)(nil), expected: "NULL"},
+		{name: "pointer", arg: &n, expected: "pointer: integer"},
+		{name: "named string", arg: status("a"), expected: "named string"},
+		{name: "bytes", arg: []byte{1}, expected: "binary"},
+		{name: "named bytes", arg: blob{1}, expected: "binary"},
+		{name: "hardware address", arg: net.HardwareAddr{1}, expected: "fmt.Stringer"},
+		{name: "raw", arg: Current, expected: "raw SQL"},
+		{name: "cast", arg: Cast("1", "INTEGER"), expected: "cast: string"},
+		{name: "struct", arg: struct{ A int }{1}, expected: "JSON"},
+		{name: "nested slices", arg: [][]int{{1}}, expected: "collection"},
+		{name: "valuer", arg: valuerFunc(func() (driver.Value, error) { return "a", nil }), expected: "driver.Valuer: string"},
+		{name: "null", arg: sql.NullInt64{}, expected: "driver.Valuer: NULL"},
+		{name: "cast pointer", arg: Cast(&n, "INTEGER"), expected: "cast: pointer: integer"},
+		{name: "unsupported", arg: make(chan int), expected: "unsupported"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tr argTrace
+			(&Interpolator{}).format(tt.arg, &tr)
+			if got := tr.String(); got != tt.expected {
+				t.Errorf("format() branch = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExplainCyclicValuer(t *testing.T) {
+	got, err := Explain("SELECT $1", cyclicValuer{})
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	if len(got) != 1 || got[0].Err == nil {
+		t.Fatalf("Explain() = %+v, want an error for the argument", got)
+	}
+}
+
+func TestExplainQueryErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		ip      *Interpolator
+		query   string
+		args    []interface{}
+		wantErr error
+	}{
+		{
+			name:    "mixed styles",
+			ip:      &Interpolator{},
+			query:   "SELECT $1, ?",
+			args:    []interface{}{1},
+			wantErr: errMixedStyles,
+		},
+		{
+			name:    "unused argument",
+			ip:      &Interpolator{},
+			query:   "SELECT $1",
+			args:    []interface{}{1, 2},
+			wantErr: ErrTooManyArgs,
+		},
+		{
+			name:    "strict missing argument",
+			ip:      &Interpolator{StrictArgs: true},
+			query:   "SELECT $1, $2",
+			args:    []interface{}{1},
+			wantErr: ErrTooFewArgs,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.ip.Explain(tt.query, tt.args...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Explain() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package informix
+
+import (
+	"strings"
+	"testing"
+)
+
+// literals returns the string literals of query, which must scan.
+func literals(t *testing.T, query string) []string {
+	var lits []string
+	for i := 0; i < len(query); {
+		j, err := skipNonCode(query, i)
+		if err != nil {
+			t.Fatalf("%q does not scan: %v", query, err)
+		}
+		if j == i {
+			i++
+			continue
+		}
+		if query[i] == '\'' {
+			lits = append(lits, query[i:j])
+		}
+		i = j
+	}
+	return lits
+}
+
+func FuzzInterpolateQuery(f *testing.F) {
+	seeds := []struct {
+		query, arg string
+	}{
+		{"SELECT * FROM users WHERE id = $1 AND name = $2", "John"},
+		{"SELECT * FROM users WHERE first = $1 OR last = $1", "O'Brien"},
+		{"SELECT * FROM t WHERE a = ? AND b = ?", "it's"},
+		{"SELECT * FROM t -- see ticket ?1234 and $1\nWHERE id = ?", `C:\temp`},
+		{"SELECT /* $1 ? */ name FROM t WHERE id = $1", "a\x00b\rc\x1ad"},
+		{"SELECT 'what?', 'it''s $1' FROM t WHERE a = $1", "''"},
+		{`SELECT "odd?name" FROM t WHERE a = ?`, `\'`},
+		{"SELECT $body$ it's $1 and $$ $body$, $1", "$$"},
+		{"SELECT 1-?", "-1"},
+		{"SELECT E?", `\x27`},
+		{"SELECT ?'x'", "y"},
+		{"SELECT * FROM t WHERE name LIKE ?", "100%_\xbf\x5c'"},
+	}
+	for _, s := range seeds {
+		f.Add(s.query, s.arg)
+	}
+
+	f.Fuzz(func(t *testing.T, query, arg string) {
+		phs, err := defaultInterpolator().scanPlaceholders(query)
+		if err != nil {
+			return
+		}
+		n := assignIndexes(phs, 1)
+		if n == 0 || n > 16 {
+			return
+		}
+		for _, ph := range phs {
+			if ph.index < 0 {
+				return
+			}
+		}
+		args := make([]interface{}, n)
+		for i := range args {
+			args[i] = arg
+		}
+		want := len(literals(t, query))
+		for _, ph := range phs {
+			if ph.index < len(args) {
+				want++
+			}
+		}
+
+		for _, d := range []Dialect{Postgres, Informix, MySQL} {
+			ip := &Interpolator{Dialect: d}
+
+			lit := strings.TrimPrefix(ip.escapeString(arg), "X")
+			if strings.Count(lit, "'")%2 != 0 {
+				t.Fatalf("dialect %d: %q has an odd number of quotes", d, lit)
+			}
+			if lits := literals(t, lit); len(lits) != 1 || lits[0] != lit {
+				t.Fatalf("dialect %d: %q is not a single literal", d, lit)
+			}
+
+			got, err := ip.InterpolateQuery(query, args...)
+			if err != nil {
+				continue
+			}
+			left, err := ip.scanPlaceholders(got)
+			if err != nil {
+				t.Fatalf("dialect %d: output %q does not scan: %v", d, got, err)
+			}
+			if len(left) != 0 {
+				t.Fatalf("dialect %d: placeholder %q survives in %q", d, got[left[0].start:left[0].end], got)
+			}
+			if lits := literals(t, got); len(lits) != want {
+				t.Fatalf("dialect %d: %q has %d literals, want %d", d, got, len(lits), want)
+			}
+		}
+	})
+}
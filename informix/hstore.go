@@ -0,0 +1,33 @@
+package informix
+
+import (
+	"sort"
+	"strings"
+)
+
+// hstoreEscaper escapes the characters that are special inside a
+// double quoted hstore key or value.
+var hstoreEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// HStore is a set of key/value pairs stored in the hstore text format,
+// such as "k1"=>"v1","k2"=>"v2". Pairs are written in key order. A nil
+// HStore is written as NULL and an empty one as the empty string.
+type HStore map[string]string
+
+// text returns h in the hstore text format, before SQL quoting.
+func (h HStore) text() string {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(`"` + hstoreEscaper.Replace(k) + `"=>"` + hstoreEscaper.Replace(h[k]) + `"`)
+	}
+	return b.String()
+}
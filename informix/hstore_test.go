@@ -0,0 +1,57 @@
+package informix
+
+import "testing"
+
+func TestHStore(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  Dialect
+		input    HStore
+		expected string
+	}{
+		{
+			name:     "multiple entries",
+			input:    HStore{"b": "2", "a": "1", "c": "three"},
+			expected: `'"a"=>"1","b"=>"2","c"=>"three"'`,
+		},
+		{
+			name:     "quotes",
+			input:    HStore{`say "hi"`: "it's"},
+			expected: `'"say \"hi\""=>"it''s"'`,
+		},
+		{
+			name:     "backslash",
+			input:    HStore{"path": `C:\temp`},
+			expected: `'"path"=>"C:\\temp"'`,
+		},
+		{
+			name:     "backslash in mysql",
+			dialect:  MySQL,
+			input:    HStore{"path": `C:\temp`},
+			expected: `'"path"=>"C:\\\\temp"'`,
+		},
+		{
+			name:     "empty",
+			input:    HStore{},
+			expected: "''",
+		},
+		{
+			name:     "nil",
+			input:    nil,
+			expected: "NULL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip := &Interpolator{Dialect: tt.dialect}
+			got, err := ip.formatArgument(tt.input)
+			if err != nil {
+				t.Fatalf("formatArgument() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package informix
+
+import (
+	"fmt"
+	"strings"
+)
+
+// QuoteIdentifier returns name quoted as a delimited SQL identifier.
+// Embedded double quotes are doubled. Empty names and names containing
+// NUL are rejected. Informix only honours delimited identifiers when
+// DELIMIDENT is set.
+func QuoteIdentifier(name string) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("empty identifier")
+	}
+	if strings.IndexByte(name, 0) >= 0 {
+		return "", fmt.Errorf("identifier %q contains NUL", name)
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`, nil
+}
+
+// QuoteIdentifiers returns names quoted with QuoteIdentifier and
+// separated by commas, for a column list built at run time. It fails if
+// there are no names or if any name cannot be quoted.
+func QuoteIdentifiers(names ...string) (string, error) {
+	if len(names) == 0 {
+		return "", fmt.Errorf("no identifiers")
+	}
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		q, err := QuoteIdentifier(name)
+		if err != nil {
+			return "", err
+		}
+		quoted[i] = q
+	}
+	return strings.Join(quoted, ", "), nil
+}
+
+// FormatSelectList returns a comma separated list of quoted columns,
+// suitable for a SELECT clause built from client supplied field names.
+// Every column must be present in allowed. An empty column list is an
+// error.
+func FormatSelectList(columns []string, allowed map[string]bool) (string, error) {
+	return defaultInterpolator().FormatSelectList(columns, allowed)
+}
+
+// FormatSelectList is like the package level FormatSelectList, but an
+// empty column list yields "*" when ip.SelectAllOnEmpty is set.
+func (ip *Interpolator) FormatSelectList(columns []string, allowed map[string]bool) (string, error) {
+	if len(columns) == 0 {
+		if ip.SelectAllOnEmpty {
+			return "*", nil
+		}
+		return "", fmt.Errorf("no columns selected")
+	}
+	for _, c := range columns {
+		if !allowed[c] {
+			return "", fmt.Errorf("column %q is not allowed", c)
+		}
+	}
+	return QuoteIdentifiers(columns...)
+}
+
+// Column is an argument that names a column. It is rendered with
+// QuoteIdentifier rather than as a string literal.
+type Column string
@@ -0,0 +1,148 @@
+package informix
+
+import "testing"
+
+func TestQuoteIdentifier(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "plain",
+			input:    "name",
+			expected: `"name"`,
+		},
+		{
+			name:     "embedded quote",
+			input:    `we"ird`,
+			expected: `"we""ird"`,
+		},
+		{
+			name:    "empty",
+			input:   "",
+			wantErr: true,
+		},
+		{
+			name:    "NUL",
+			input:   "a\x00b",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := QuoteIdentifier(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("QuoteIdentifier() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.expected {
+				t.Errorf("QuoteIdentifier() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestQuoteIdentifiers(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "list",
+			input:    []string{"id", "name", "created"},
+			expected: `"id", "name", "created"`,
+		},
+		{
+			name:     "single",
+			input:    []string{"id"},
+			expected: `"id"`,
+		},
+		{
+			name:     "embedded quote",
+			input:    []string{"id", `we"ird`},
+			expected: `"id", "we""ird"`,
+		},
+		{
+			name:    "empty name",
+			input:   []string{"id", ""},
+			wantErr: true,
+		},
+		{
+			name:    "NUL",
+			input:   []string{"a\x00b"},
+			wantErr: true,
+		},
+		{
+			name:    "no names",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := QuoteIdentifiers(tt.input...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("QuoteIdentifiers() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.expected {
+				t.Errorf("QuoteIdentifiers() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatSelectList(t *testing.T) {
+	allowed := map[string]bool{"id": true, "name": true, "email": true}
+
+	tests := []struct {
+		name     string
+		ip       *Interpolator
+		columns  []string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "allowed columns",
+			ip:       &Interpolator{},
+			columns:  []string{"id", "name"},
+			expected: `"id", "name"`,
+		},
+		{
+			name:    "disallowed column",
+			ip:      &Interpolator{},
+			columns: []string{"id", "password"},
+			wantErr: true,
+		},
+		{
+			name:    "empty input",
+			ip:      &Interpolator{},
+			columns: nil,
+			wantErr: true,
+		},
+		{
+			name:     "empty input selects all",
+			ip:       &Interpolator{SelectAllOnEmpty: true},
+			columns:  []string{},
+			expected: "*",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.ip.FormatSelectList(tt.columns, allowed)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FormatSelectList() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.expected {
+				t.Errorf("FormatSelectList() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
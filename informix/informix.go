@@ -1,118 +1,1478 @@
 package informix
 
 import (
+	"context"
+	"database/sql"
 	"database/sql/driver"
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/big"
+	"net"
 	"reflect"
-	"regexp"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 )
 
+// Dialect identifies the SQL flavour that values are rendered for.
+type Dialect int
+
+const (
+	// Postgres renders values the way PostgreSQL expects them. It is the
+	// zero Dialect and matches the historical output of this package.
+	Postgres Dialect = iota
+
+	// Informix renders values for Informix Dynamic Server.
+	Informix
+
+	// MySQL renders values for MySQL and MariaDB servers that run
+	// without the NO_BACKSLASH_ESCAPES SQL mode, over a connection
+	// that uses a UTF-8 character set. Strings that are not valid
+	// UTF-8 are written as hex literals, so that a multi-byte
+	// sequence cannot swallow the backslash of an escape.
+	MySQL
+)
+
+// Style selects the placeholder syntax of queries.
+type Style int
+
+const (
+	// StyleAuto accepts both ? and $n placeholders. It is the zero
+	// Style.
+	StyleAuto Style = iota
+
+	// StyleQuestion accepts only ? placeholders.
+	StyleQuestion
+
+	// StyleDollar accepts only $n placeholders.
+	StyleDollar
+
+	// StyleColonNumeric accepts only Oracle style :n placeholders,
+	// numbered like $n. A :: cast is not a placeholder.
+	StyleColonNumeric
+
+	// StyleNamed accepts only named placeholders such as :name, which
+	// are bound with InterpolateNamed. Functions taking positional
+	// arguments reject it.
+	StyleNamed
+)
+
+// EmptySliceMode selects how an empty slice argument is written. A slice
+// is written as a parenthesized list, so a query takes it as x IN $1,
+// without parentheses of its own. A nil slice is not empty but NULL,
+// like any other typed nil.
+type EmptySliceMode int
+
+const (
+	// EmptyParens writes (), as earlier versions did. Most servers
+	// reject it as a syntax error.
+	EmptyParens EmptySliceMode = iota
+
+	// EmptyNull writes (NULL), so that x IN $1 matches no rows.
+	EmptyNull
+
+	// EmptyError makes interpolation fail with ErrEmptySlice.
+	EmptyError
+
+	// EmptyCollection writes the empty collection of the Dialect,
+	// ARRAY[] or LIST{}.
+	EmptyCollection
+)
+
+// TimePreset names a fixed layout for time.Time values written as
+// quoted strings.
+type TimePreset int
+
+const (
+	// TimePresetDefault writes the date and time to the microsecond,
+	// as set by FractionDigits and FixedFraction.
+	TimePresetDefault TimePreset = iota
+
+	// TimePresetInformixSecond writes a DATETIME YEAR TO SECOND value,
+	// such as '2024-02-12 15:04:05'.
+	TimePresetInformixSecond
+
+	// TimePresetInformixFraction5 writes a DATETIME YEAR TO
+	// FRACTION(5) value, such as '2024-02-12 15:04:05.12345'.
+	TimePresetInformixFraction5
+
+	// TimePresetISO8601 writes an ISO 8601 timestamp with its zone
+	// offset, such as '2024-02-12T15:04:05.123456Z'.
+	TimePresetISO8601
+
+	// TimePresetDateOnly writes the date alone, such as '2024-02-12'.
+	TimePresetDateOnly
+)
+
+// timePresetLayouts holds the layout of each TimePreset but the
+// default.
+var timePresetLayouts = map[TimePreset]string{
+	TimePresetInformixSecond:    "2006-01-02 15:04:05",
+	TimePresetInformixFraction5: "2006-01-02 15:04:05.00000",
+	TimePresetISO8601:           "2006-01-02T15:04:05.999999Z07:00",
+	TimePresetDateOnly:          "2006-01-02",
+}
+
+// ControlCharMode selects what happens to a leading byte order mark and
+// to C0 control characters, other than tab, newline and carriage
+// return, in string values.
+type ControlCharMode int
+
+const (
+	// KeepControlChars writes strings as they are.
+	KeepControlChars ControlCharMode = iota
+
+	// StripControlChars removes a leading byte order mark (U+FEFF) and
+	// the control characters.
+	StripControlChars
+
+	// RejectControlChars makes formatting fail for a string that
+	// starts with a byte order mark or contains a control character.
+	RejectControlChars
+)
+
+// QuoteEscapeMode selects how a single quote inside a string literal is
+// escaped.
+type QuoteEscapeMode int
+
+const (
+	// DoubleQuote writes a quote as two quotes (''), as standard SQL
+	// does.
+	DoubleQuote QuoteEscapeMode = iota
+
+	// BackslashQuote writes a quote as \', for servers and gateways
+	// that treat backslash as an escape character, such as MySQL
+	// without ANSI_QUOTES. Backslashes are doubled as well, so that a
+	// backslash in the value cannot escape the closing quote.
+	BackslashQuote
+)
+
+// BinaryEncoding selects how binary data is written in literals.
+type BinaryEncoding int
+
+const (
+	// HexEncoding writes binary data in the hex form of the Dialect.
+	HexEncoding BinaryEncoding = iota
+
+	// Base64Encoding writes binary data as a quoted base64 string.
+	// Decoding it is left to the application.
+	Base64Encoding
+
+	// BackslashHexEncoding writes binary data as a quoted hex string
+	// with a \x prefix ('\x010203'), the bytea form of Postgres, in
+	// every dialect. The backslash is escaped like any other in a
+	// string, so MySQL, and servers that take backslash escapes,
+	// get '\\x010203'.
+	BackslashHexEncoding
+
+	// PrefixHexEncoding writes binary data as an unquoted hex number
+	// with a 0x prefix (0x010203), as understood by MySQL and SQL Server.
+	PrefixHexEncoding
+
+	// ANSIHexEncoding writes binary data as a standard SQL hex string
+	// (X'010203'), in every dialect.
+	ANSIHexEncoding
+)
+
+// Interpolator renders queries and values according to its settings.
+// The zero value renders for the Postgres dialect.
+type Interpolator struct {
+	// Dialect selects the SQL flavour of the generated literals.
+	Dialect Dialect
+
+	// Style selects the placeholder syntax. By default both ? and $n
+	// placeholders are recognized.
+	Style Style
+
+	// StrictArgs makes InterpolateQuery fail with ErrTooFewArgs when
+	// a placeholder has no matching argument. By default such
+	// placeholders are left in the query as they are.
+	StrictArgs bool
+
+	// ExtendedStrings writes Postgres string literals as E'...'
+	// escape strings, with backslashes doubled, for servers that run
+	// with standard_conforming_strings off. It has no effect on other
+	// dialects.
+	ExtendedStrings bool
+
+	// EmptySlice selects how an empty slice argument is written.
+	EmptySlice EmptySliceMode
+
+	// Collections writes slices, other than []byte and text, as
+	// collection literals of the Dialect, LIST{...} or ARRAY[...],
+	// rather than as parenthesized IN lists. EmptySlice does not
+	// apply to them.
+	Collections bool
+
+	// QuoteEscape selects how single quotes in strings are escaped.
+	QuoteEscape QuoteEscapeMode
+
+	// EmptyStringAsNull writes empty strings as NULL rather than ''.
+	// It applies to string arguments, including named string types
+	// and the string of a valid sql.NullString; other text types,
+	// such as Text, keep their empty value.
+	EmptyStringAsNull bool
+
+	// RatScale is the number of decimal places *big.Rat values are
+	// rounded to, as for a MONEY or DECIMAL(p,s) column. Zero means
+	// 2, and a negative value means none.
+	RatScale int
+
+	// ControlChars selects how a leading byte order mark and control
+	// characters in strings, such as those copied from Windows
+	// sources, are handled. By default they are kept.
+	ControlChars ControlCharMode
+
+	// BinaryEncoding selects how []byte values are written.
+	BinaryEncoding BinaryEncoding
+
+	// MaxInlineBytes is the longest binary value, in bytes, that is
+	// written into a query. Longer values are rejected. Zero means no
+	// limit.
+	MaxInlineBytes int
+
+	// ZeroTimeAsNull renders the zero time.Time as NULL rather than
+	// as the out of range timestamp '0001-01-01 00:00:00'.
+	ZeroTimeAsNull bool
+
+	// Location, if set, is the time zone time.Time values are converted
+	// to before they are written, so that columns holding local wall
+	// clock time get the time in that zone. By default a value is
+	// written in its own location. The zero time is never converted.
+	Location *time.Location
+
+	// FixedFraction writes the fraction of a second of time.Time
+	// values with all FractionDigits digits, as in 15:04:05.100000, for
+	// columns that expect a fixed width. By default trailing zeros, and
+	// a zero fraction, are left out.
+	FixedFraction bool
+
+	// TimePreset selects a fixed layout for time.Time values, in place
+	// of FractionDigits and FixedFraction. It does not apply to typed
+	// literals.
+	TimePreset TimePreset
+
+	// FractionDigits is the largest number of digits written for the
+	// fraction of a second of time.Time and TimeOnly values, from 1 to
+	// 6, or NoFraction to leave the fraction out. Extra digits are
+	// truncated, not rounded. Zero means 6, and Informix, whose
+	// FRACTION holds at most five digits, never gets more than 5.
+	FractionDigits int
+
+	// TypedTimeLiterals writes time.Time values as typed literals, such
+	// as TIMESTAMP '2024-02-12 15:04:05', or for Informix
+	// DATETIME(2024-02-12 15:04:05) YEAR TO SECOND, rather than as
+	// quoted strings.
+	TypedTimeLiterals bool
+
+	// MidnightAsDate makes TypedTimeLiterals write a time at midnight
+	// as a date, such as DATE '2024-02-12', or for Informix
+	// DATETIME(2024-02-12) YEAR TO DAY.
+	MidnightAsDate bool
+
+	// AllowMixedStyles permits queries that use both ? and $n
+	// placeholders. Each ? then takes the argument after the one
+	// taken by the previous ?, regardless of any $n.
+	AllowMixedStyles bool
+
+	// PlaceholderBase is the number of the $n placeholder that refers
+	// to the first argument, for queries from generators that number
+	// them differently. Zero means 1; use ZeroBase to make $0 the first
+	// argument. Other negative values are an error. Placeholders
+	// numbered below the base are out of range.
+	PlaceholderBase int
+
+	// NamePrefix is the character that starts a placeholder in
+	// InterpolateNamed, usually ':' or '@'. It defaults to ':'.
+	NamePrefix byte
+
+	// SelectAllOnEmpty makes FormatSelectList return "*" for an empty
+	// column list instead of an error.
+	SelectAllOnEmpty bool
+
+	// MaxQuerySize is the longest query, in bytes, that InterpolateQuery
+	// builds. Once the output grows past it, building stops and
+	// ErrQueryTooLarge is returned. Zero means no limit.
+	MaxQuerySize int
+
+	// BoolAsInt renders booleans as 1 and 0, for boolean-like values
+	// stored in integer columns such as SMALLINT, in every dialect.
+	BoolAsInt bool
+
+	// ctx is checked for cancellation while formatting long slices.
+	// It is set by InterpolateQueryContext.
+	ctx context.Context
+}
+
+var (
+	// ErrTooFewArgs is returned when a query has more placeholders than
+	// arguments.
+	ErrTooFewArgs = errors.New("not enough arguments provided")
+
+	// ErrTooManyArgs is returned when some arguments are not used by
+	// the query.
+	ErrTooManyArgs = errors.New("too many arguments provided")
+
+	// ErrUnsupportedType is returned for arguments whose type has no
+	// SQL literal form.
+	ErrUnsupportedType = errors.New("unsupported argument type")
+
+	// ErrEmptySlice is returned for an empty slice argument when the
+	// EmptySlice option is EmptyError.
+	ErrEmptySlice = errors.New("empty slice argument")
+
+	// ErrQueryTooLarge is returned when an interpolated query would be
+	// longer than the MaxQuerySize option allows.
+	ErrQueryTooLarge = errors.New("interpolated query too large")
+)
+
+// decimal is implemented by exact decimal types such as
+// github.com/shopspring/decimal.Decimal. Their values are rendered as
+// unquoted numbers, with no loss of precision.
+type decimal interface {
+	String() string
+	Coefficient() *big.Int
+	Exponent() int32
+}
+
+// maxValuerDepth limits how many driver.Valuer results are resolved in
+// turn, so that a Valuer that returns itself cannot loop forever.
+const maxValuerDepth = 16
+
+// Char is an argument holding a single character. It is rendered as a
+// one character string literal, whereas a plain rune is rendered as
+// its integer code point.
+type Char rune
+
+// Runes is a rune slice argument holding text. It is rendered as a
+// string literal, whereas a plain []rune, being a []int32, is rendered
+// as a list of integer code points.
+type Runes []rune
+
+// Text is a byte slice argument holding UTF-8 text. It is rendered as
+// a string literal, whereas a plain []byte is rendered as binary data.
+type Text []byte
+
+// defaultDialect is the Dialect used by the package level functions.
+var defaultDialect atomic.Int32
+
+// SetDefaultDialect sets the Dialect used by the package level
+// functions, such as InterpolateQuery. It is meant to be called once at
+// start up. It is safe to call while queries are being interpolated,
+// but a query being interpolated at the time may use either dialect.
+func SetDefaultDialect(d Dialect) {
+	defaultDialect.Store(int32(d))
+}
+
+// DefaultDialect returns the Dialect used by the package level
+// functions. It is Postgres unless changed with SetDefaultDialect.
+func DefaultDialect() Dialect {
+	return Dialect(defaultDialect.Load())
+}
+
+// defaultInterpolator returns the Interpolator used by the package
+// level functions.
+func defaultInterpolator() *Interpolator {
+	return &Interpolator{Dialect: DefaultDialect()}
+}
+
 // InterpolateQuery takes a SQL query with placeholders and arguments,
 // and returns a safe SQL string with properly escaped and formatted values.
+// Placeholders are checked against the arguments before any value is
+// formatted, and on error the returned string is always empty.
 func InterpolateQuery(query string, args ...interface{}) (string, error) {
-	if len(args) == 0 {
-		return query, nil
+	return defaultInterpolator().InterpolateQuery(query, args...)
+}
+
+// MustInterpolateQuery is like InterpolateQuery but panics if the query
+// cannot be interpolated. It simplifies the use of constant query
+// templates whose placeholders are known to match their arguments;
+// queries built at run time should use InterpolateQuery.
+func MustInterpolateQuery(query string, args ...interface{}) string {
+	s, err := InterpolateQuery(query, args...)
+	if err != nil {
+		panic(`informix: InterpolateQuery(` + strconv.Quote(query) + `): ` + err.Error())
 	}
+	return s
+}
 
-	// Handle different placeholder styles ($1, $2) or (?)
-	placeholder := regexp.MustCompile(`\$\d+|\?`)
-	argPosition := 0
+// InterpolateQuery is like the package level InterpolateQuery, but
+// renders values using the settings of ip.
+func (ip *Interpolator) InterpolateQuery(query string, args ...interface{}) (string, error) {
+	s, _, err := ip.interpolate(query, args, false)
+	return s, err
+}
 
-	interpolated := placeholder.ReplaceAllStringFunc(query, func(match string) string {
-		if argPosition >= len(args) {
-			return match // Not enough arguments provided
-		}
+// InterpolateQueryContext is like InterpolateQuery, but stops early and
+// returns ctx.Err() if ctx is done while formatting long slices, such
+// as huge IN-lists.
+func InterpolateQueryContext(ctx context.Context, query string, args ...interface{}) (string, error) {
+	return defaultInterpolator().InterpolateQueryContext(ctx, query, args...)
+}
+
+// InterpolateQueryContext is like the package level
+// InterpolateQueryContext, but renders values using the settings of ip.
+func (ip *Interpolator) InterpolateQueryContext(ctx context.Context, query string, args ...interface{}) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	c := *ip
+	c.ctx = ctx
+	return c.InterpolateQuery(query, args...)
+}
+
+// contextCheckInterval is the number of slice elements formatted
+// between checks of the context passed to InterpolateQueryContext.
+const contextCheckInterval = 1024
 
-		// Get the current argument
-		arg := args[argPosition]
-		argPosition++
+// checkContext returns the error of ip.ctx, if any, when formatting
+// the i-th element of a slice. It only looks at the context every
+// contextCheckInterval elements.
+func (ip *Interpolator) checkContext(i int) error {
+	if ip.ctx == nil || i%contextCheckInterval != contextCheckInterval-1 {
+		return nil
+	}
+	return ip.ctx.Err()
+}
 
-		return formatArgument(arg)
+// checkSize returns ErrQueryTooLarge if n bytes of output exceed
+// ip.MaxQuerySize.
+func (ip *Interpolator) checkSize(n int) error {
+	if ip.MaxQuerySize > 0 && n > ip.MaxQuerySize {
+		return fmt.Errorf("%w: more than %d bytes", ErrQueryTooLarge, ip.MaxQuerySize)
+	}
+	return nil
+}
+
+// InterpolateQueryDetailed is like InterpolateQuery, but also returns
+// the sorted indices of the arguments that the query refers to, each
+// once however many placeholders refer to it. Unlike InterpolateQuery,
+// it does not fail for an argument that no placeholder refers to, such
+// as the second argument of a query using $1 and $3; such arguments
+// are left out of used, so that len(used) < len(args) flags them.
+func InterpolateQueryDetailed(query string, args ...interface{}) (string, []int, error) {
+	return defaultInterpolator().InterpolateQueryDetailed(query, args...)
+}
+
+// InterpolateQueryDetailed is like the package level
+// InterpolateQueryDetailed, but renders values using the settings of ip.
+func (ip *Interpolator) InterpolateQueryDetailed(query string, args ...interface{}) (string, []int, error) {
+	return ip.interpolate(query, args, true)
+}
+
+// interpolate implements InterpolateQuery and InterpolateQueryDetailed.
+// Arguments that no placeholder refers to are an error unless
+// allowUnused is set. A query is scanned even without arguments, so
+// that an unterminated quote is reported whatever the arguments.
+func (ip *Interpolator) interpolate(query string, args []interface{}, allowUnused bool) (string, []int, error) {
+	phs, err := ip.placeholders(query, len(args), allowUnused)
+	if err != nil {
+		return "", nil, err
+	}
+
+	interpolated, err := replacePlaceholdersLimit(query, phs, ip.checkSize, func(ph placeholder) (string, error) {
+		if ph.index < 0 || ph.index >= len(args) {
+			return query[ph.start:ph.end], nil // Not enough arguments provided
+		}
+		s, err := ip.formatArgument(args[ph.index])
+		if err != nil {
+			return "", fmt.Errorf("argument %d: %w", ph.index+1, err)
+		}
+		return s, nil
 	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return interpolated, usedArguments(phs, len(args)), nil
+}
 
-	if argPosition < len(args) {
-		return "", fmt.Errorf("too many arguments provided: expected %d, got %d", argPosition, len(args))
+// placeholders returns the placeholders of query, with the indexes of
+// the arguments they refer to, after checking them against the nargs
+// arguments as ip's settings ask. The checks come before formatting
+// anything, so that counting errors are found first.
+func (ip *Interpolator) placeholders(query string, nargs int, allowUnused bool) ([]placeholder, error) {
+	phs, err := ip.scanPlaceholders(query)
+	if err != nil {
+		return nil, err
+	}
+	if !ip.AllowMixedStyles {
+		if err := checkMixedStyles(phs); err != nil {
+			return nil, err
+		}
 	}
+	assignIndexes(phs, ip.dollarBase())
 
-	return interpolated, nil
+	if ip.StrictArgs {
+		for _, ph := range phs {
+			if ph.index < 0 || ph.index >= nargs {
+				return nil, newQueryError(query, ph.start, fmt.Errorf("%w: placeholder %s has no argument, got %d", ErrTooFewArgs, query[ph.start:ph.end], nargs))
+			}
+		}
+	}
+	if i := unusedArgument(phs, nargs); i >= 0 && !allowUnused {
+		return nil, fmt.Errorf("%w: argument %d is not used, got %d", ErrTooManyArgs, i+1, nargs)
+	}
+	return phs, nil
+}
+
+// ZeroBase is the PlaceholderBase setting that makes $0 refer to the
+// first argument.
+const ZeroBase = -1
+
+// dollarBase returns the number of the $n placeholder that refers to
+// the first argument.
+func (ip *Interpolator) dollarBase() int {
+	switch {
+	case ip.PlaceholderBase == ZeroBase:
+		return 0
+	case ip.PlaceholderBase == 0:
+		return 1
+	}
+	return ip.PlaceholderBase
+}
+
+// formatArgument converts a Go value to its SQL string representation
+// using the default settings.
+func formatArgument(arg interface{}) (string, error) {
+	return defaultInterpolator().formatArgument(arg)
 }
 
 // formatArgument converts a Go value to its SQL string representation
-func formatArgument(arg interface{}) string {
+func (ip *Interpolator) formatArgument(arg interface{}) (string, error) {
+	return ip.format(arg, nil)
+}
+
+// format implements formatArgument, recording the kinds of formatting
+// it applies in tr, if tr is not nil.
+func (ip *Interpolator) format(arg interface{}, tr *argTrace) (string, error) {
+	k, v, err := ip.classify(arg, tr)
+	if err != nil {
+		return "", err
+	}
+	switch k {
+	case kindNull:
+		return "NULL", nil
+	case kindRaw, kindNumber:
+		return v.(string), nil
+	case kindBool:
+		return ip.formatBool(v.(bool)), nil
+	case kindInteger, kindUnsigned:
+		return fmt.Sprintf("%d", v), nil
+	case kindFloat:
+		return fmt.Sprintf("%f", v), nil
+	case kindString:
+		return ip.quoteString(v.(string))
+	case kindBinary:
+		return ip.QuoteBytes(v.([]byte))
+	case kindTimestamp:
+		return ip.formatTime(v.(time.Time)), nil
+	case kindDate:
+		return ip.formatDate(v.(time.Time)), nil
+	case kindTimeOfDay:
+		return ip.formatTimeOfDay(v.(time.Time)), nil
+	case kindRow:
+		return ip.formatRow(v.(Row))
+	case kindTuples:
+		return ip.formatTuples(v.(Tuples))
+	case kindCast:
+		return ip.formatCast(v.(CastValue), tr)
+	case kindInline:
+		return ip.format(v.(Inline).Value, tr)
+	case kindList:
+		return ip.formatList(reflect.ValueOf(v))
+	case kindCollection:
+		return ip.formatCollection(reflect.ValueOf(v), false)
+	}
+	panic(fmt.Sprintf("informix: classify returned %v", k))
+}
+
+// classify works out how arg is written, recording the kinds of
+// formatting it applies in tr, if tr is not nil. It returns the kind
+// arg is rendered as, which may be more general than the kind
+// recorded, and the value to render, converted for that kind:
+//
+//   - kindNull: nil
+//   - kindRaw: SQL text written verbatim, as for Column or Raw
+//   - kindNumber: an unquoted number, as for json.Number or Money
+//   - kindBool: a bool
+//   - kindInteger, kindUnsigned: a signed or unsigned integer of any
+//     size
+//   - kindFloat: a float32 or float64
+//   - kindString: a string to be quoted
+//   - kindBinary: a []byte
+//   - kindTimestamp, kindDate, kindTimeOfDay: a time.Time
+//   - kindRow, kindTuples, kindCast, kindInline: the argument itself
+//   - kindList, kindCollection: a slice or array
+//
+// Values that need no conversion are returned as arg itself, so that
+// the common kinds cost no allocation. format renders the result and
+// Parameterize binds it, so that both treat every argument alike.
+func (ip *Interpolator) classify(arg interface{}, tr *argTrace) (argKind, interface{}, error) {
 	if arg == nil {
-		return "NULL"
+		tr.add(kindNull)
+		return kindNull, nil, nil
+	}
+
+	// Handle types with a registered formatter
+	if fn := lookupFormatter(reflect.TypeOf(arg)); fn != nil {
+		tr.add(kindFormatter)
+		s, err := fn(arg)
+		return kindRaw, s, err
 	}
 
-	// Handle values that implement driver.Valuer
-	if valuer, ok := arg.(driver.Valuer); ok {
+	// Handle enum types by formatting the value registered for them
+	if fn := lookupEnum(reflect.TypeOf(arg)); fn != nil {
+		tr.add(kindEnum)
+		v, err := fn(arg)
+		if err != nil {
+			return 0, nil, err
+		}
+		return ip.classify(v, tr)
+	}
+
+	// Handle typed nils, such as a nil *int or []int stored in an
+	// interface, before calling any of their methods
+	if rv := reflect.ValueOf(arg); isNil(rv) {
+		tr.add(kindNull)
+		return kindNull, nil, nil
+	}
+
+	// Handle sql.Null[T] by formatting its value, which may be of a
+	// type that driver.Valuer could not return
+	if v, valid, ok := nullValue(arg); ok {
+		tr.add(kindSQLNull)
+		if !valid {
+			tr.add(kindNull)
+			return kindNull, nil, nil
+		}
+		return ip.classify(v, tr)
+	}
+
+	// Handle exact decimals before driver.Valuer, which returns them
+	// as strings
+	if d, ok := arg.(decimal); ok {
+		tr.add(kindDecimal)
+		s, err := formatNumber(json.Number(d.String()))
+		return kindNumber, s, err
+	}
+
+	// Handle values that implement driver.Valuer, including valuers
+	// that return another driver.Valuer
+	for depth := 0; ; depth++ {
+		valuer, ok := arg.(driver.Valuer)
+		if !ok {
+			break
+		}
+		tr.add(kindValuer)
+		if depth == maxValuerDepth {
+			return 0, nil, fmt.Errorf("driver.Valuer %T nested more than %d levels deep", arg, maxValuerDepth)
+		}
 		val, err := valuer.Value()
 		if err != nil {
-			return "NULL"
+			return 0, nil, err
+		}
+		// A valuer may return a typed nil, such as a nil *time.Time,
+		// which is NULL just like a nil argument
+		if val == nil || isNil(reflect.ValueOf(val)) {
+			tr.add(kindNull)
+			return kindNull, nil, nil
 		}
 		arg = val
 	}
 
 	switch v := arg.(type) {
 	case bool:
-		return strconv.FormatBool(v)
+		tr.add(kindBool)
+		return kindBool, arg, nil
 
+	// rune is an alias for int32, so a rune argument cannot be told
+	// apart from an int32 and is formatted as a number. Use Char to
+	// pass a single character.
 	case int, int8, int16, int32, int64:
-		return fmt.Sprintf("%d", v)
+		tr.add(kindInteger)
+		return kindInteger, arg, nil
 
 	case uint, uint8, uint16, uint32, uint64:
-		return fmt.Sprintf("%d", v)
+		tr.add(kindUnsigned)
+		return kindUnsigned, arg, nil
 
 	case float32, float64:
-		return fmt.Sprintf("%f", v)
+		tr.add(kindFloat)
+		return kindFloat, arg, nil
 
 	case string:
-		return escapeString(v)
+		tr.add(kindString)
+		if v == "" && ip.EmptyStringAsNull {
+			return kindNull, nil, nil
+		}
+		return kindString, arg, nil
+
+	case Char:
+		tr.add(kindString)
+		return kindString, string(rune(v)), nil
+
+	case LikeValue:
+		tr.add(kindLike)
+		return kindString, EscapeLike(string(v)), nil
+
+	case HStore:
+		tr.add(kindHStore)
+		if v == nil {
+			return kindNull, nil, nil
+		}
+		return kindString, v.text(), nil
+
+	// net.IP is a []byte, but is stored in its text form
+	case net.IP:
+		tr.add(kindNetwork)
+		if len(v) == 0 {
+			return kindNull, nil, nil
+		}
+		return kindString, v.String(), nil
+
+	case net.IPNet:
+		tr.add(kindNetwork)
+		return kindString, v.String(), nil
+
+	case *net.IPNet:
+		tr.add(kindNetwork)
+		if v == nil {
+			return kindNull, nil, nil
+		}
+		return kindString, v.String(), nil
 
 	case []byte:
-		return formatBytes(v)
+		tr.add(kindBinary)
+		return kindBinary, arg, nil
+
+	case Text:
+		tr.add(kindString)
+		return kindString, string(v), nil
+
+	// sql.RawBytes comes from scanning a column, which is nearly always
+	// text, so it is written as a string rather than as binary data.
+	// Its contents are assumed to be UTF-8.
+	case sql.RawBytes:
+		tr.add(kindString)
+		if v == nil {
+			return kindNull, nil, nil
+		}
+		return kindString, string(v), nil
+
+	case Runes:
+		tr.add(kindString)
+		return kindString, string(v), nil
 
 	case time.Time:
-		return fmt.Sprintf("'%s'", v.Format("2006-01-02 15:04:05.999999"))
+		tr.add(kindTimestamp)
+		if ip.ZeroTimeAsNull && v.IsZero() {
+			return kindNull, nil, nil
+		}
+		return kindTimestamp, arg, nil
+
+	case *time.Time:
+		tr.add(kindTimestamp)
+		// Caught here, as *time.Time would otherwise be formatted by
+		// its MarshalText method. Nil pointers, whether passed
+		// directly or returned by a driver.Valuer, are NULL above.
+		return ip.classifyTime(kindTimestamp, *v)
+
+	case DateOnly:
+		tr.add(kindDate)
+		return ip.classifyTime(kindDate, time.Time(v))
+
+	case TimeOnly:
+		tr.add(kindTimeOfDay)
+		return ip.classifyTime(kindTimeOfDay, time.Time(v))
+
+	case Interval:
+		tr.add(kindInterval)
+		s, err := v.literal()
+		return kindRaw, s, err
+
+	case Column:
+		tr.add(kindIdentifier)
+		s, err := QuoteIdentifier(string(v))
+		return kindRaw, s, err
+
+	case Raw:
+		tr.add(kindRaw)
+		return kindRaw, string(v), nil
+
+	case Serial:
+		tr.add(kindSerial)
+		return kindRaw, "0", nil
+
+	case Money:
+		tr.add(kindMoney)
+		return kindNumber, v.literal(), nil
+
+	// Written as an exact decimal, rather than by its MarshalText
+	// method, which gives a fraction such as 1/3
+	case *big.Rat:
+		tr.add(kindDecimal)
+		return kindNumber, v.FloatString(ip.ratScale()), nil
+
+	case ColumnInterval:
+		tr.add(kindInterval)
+		s, err := v.expression()
+		return kindRaw, s, err
+
+	case Row:
+		tr.add(kindRow)
+		return kindRow, v, nil
+
+	case Tuples:
+		tr.add(kindTuples)
+		return kindTuples, v, nil
+
+	case CastValue:
+		tr.add(kindCast)
+		return kindCast, v, nil
+
+	case Inline:
+		tr.add(kindInline)
+		return kindInline, v, nil
 
 	case []interface{}:
-		return formatArray(v)
+		tr.add(kindCollection)
+		return kindCollection, arg, nil
+
+	case json.RawMessage:
+		tr.add(kindJSON)
+		if v == nil {
+			return kindNull, nil, nil
+		}
+		if !json.Valid(v) {
+			return 0, nil, fmt.Errorf("invalid JSON in json.RawMessage")
+		}
+		return kindString, string(v), nil
+
+	case map[string]interface{}:
+		tr.add(kindJSON)
+		b, err := json.Marshal(v)
+		if err != nil {
+			return 0, nil, err
+		}
+		return kindString, string(b), nil
+
+	case json.Number:
+		tr.add(kindNumber)
+		s, err := formatNumber(v)
+		return kindNumber, s, err
+
+	case [16]byte:
+		tr.add(kindUUID)
+		return kindString, formatUUID(v), nil
+	}
+
+	// Handle types that know their text or binary form, such as UUIDs
+	switch v := arg.(type) {
+	case encoding.TextMarshaler:
+		tr.add(kindTextMarshaler)
+		text, err := v.MarshalText()
+		if err != nil {
+			return 0, nil, err
+		}
+		return kindString, string(text), nil
+
+	case encoding.BinaryMarshaler:
+		tr.add(kindBinaryMarshaler)
+		data, err := v.MarshalBinary()
+		if err != nil {
+			return 0, nil, err
+		}
+		return kindBinary, data, nil
+
+	case fmt.Stringer:
+		tr.add(kindStringer)
+		return kindString, v.String(), nil
 	}
 
-	// Handle slices of basic types
 	rv := reflect.ValueOf(arg)
-	if rv.Kind() == reflect.Slice {
-		values := make([]string, rv.Len())
-		for i := 0; i < rv.Len(); i++ {
-			values[i] = formatArgument(rv.Index(i).Interface())
+
+	// Reject kinds that have no SQL literal and are passed by mistake
+	switch rv.Kind() {
+	case reflect.Uintptr, reflect.Complex64, reflect.Complex128,
+		reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		tr.add(kindUnsupported)
+		return 0, nil, fmt.Errorf("%w: %T", ErrUnsupportedType, arg)
+	}
+
+	// Handle named scalar types, such as type UserID int64, like the
+	// built-in types they are based on
+	switch rv.Kind() {
+	case reflect.Bool:
+		tr.add(kindNamedBool)
+		return ip.classify(rv.Bool(), nil)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		tr.add(kindNamedInteger)
+		return ip.classify(rv.Int(), nil)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		tr.add(kindNamedUnsigned)
+		// Kept unsigned: values above math.MaxInt64 do not fit an int64
+		return ip.classify(rv.Uint(), nil)
+	case reflect.Float32:
+		tr.add(kindNamedFloat)
+		return ip.classify(float32(rv.Float()), nil)
+	case reflect.Float64:
+		tr.add(kindNamedFloat)
+		return ip.classify(rv.Float(), nil)
+	case reflect.String:
+		tr.add(kindNamedString)
+		return ip.classify(rv.String(), nil)
+	}
+
+	// Handle pointers by formatting the value they point to
+	if rv.Kind() == reflect.Pointer {
+		tr.add(kindPointer)
+		return ip.classify(rv.Elem().Interface(), tr)
+	}
+
+	// Handle slices and arrays of basic types. A slice of slices is a
+	// multi-dimensional value rather than an IN list.
+	if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		// Named []byte types and byte arrays are binary, like []byte
+		// itself. Those with a text form, such as net.HardwareAddr,
+		// were handled by the marshaler cases above.
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			tr.add(kindBinary)
+			return kindBinary, byteValues(rv), nil
+		}
+		if ip.Collections || hasNestedCollection(rv) {
+			tr.add(kindCollection)
+			return kindCollection, arg, nil
+		}
+		tr.add(kindList)
+		return kindList, arg, nil
+	}
+
+	// Store structs and other maps as JSON documents. encoding/json
+	// writes the keys of maps in sorted order and rejects keys it
+	// cannot turn into strings.
+	if rv.Kind() == reflect.Struct || rv.Kind() == reflect.Map {
+		tr.add(kindJSON)
+		b, err := json.Marshal(arg)
+		if err != nil {
+			return 0, nil, fmt.Errorf("%w: %T: %v", ErrUnsupportedType, arg, err)
 		}
-		return fmt.Sprintf("(%s)", strings.Join(values, ","))
+		return kindString, string(b), nil
 	}
 
 	// Default to string representation
-	return escapeString(fmt.Sprintf("%v", arg))
+	tr.add(kindDefault)
+	return kindString, fmt.Sprintf("%v", arg), nil
 }
 
-// escapeString properly escapes a string for SQL
+// classifyTime returns t as a value of kind k, or NULL if it is the
+// zero time and ip.ZeroTimeAsNull is set.
+func (ip *Interpolator) classifyTime(k argKind, t time.Time) (argKind, interface{}, error) {
+	if ip.ZeroTimeAsNull && t.IsZero() {
+		return kindNull, nil, nil
+	}
+	return k, t, nil
+}
+
+// formatTime formats t as a timestamp literal.
+func (ip *Interpolator) formatTime(t time.Time) string {
+	if ip.ZeroTimeAsNull && t.IsZero() {
+		return "NULL"
+	}
+	if ip.Location != nil && !t.IsZero() {
+		t = t.In(ip.Location)
+	}
+	if layout, ok := timePresetLayouts[ip.TimePreset]; ok && !ip.TypedTimeLiterals {
+		return fmt.Sprintf("'%s'", t.Format(layout))
+	}
+	t, digits, frac := ip.fraction(t)
+	layout := "2006-01-02 15:04:05" + frac
+	if !ip.TypedTimeLiterals {
+		return fmt.Sprintf("'%s'", t.Format(layout))
+	}
+
+	date := ip.MidnightAsDate && t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0
+	switch {
+	case ip.Dialect == Informix && date:
+		return fmt.Sprintf("DATETIME(%s) YEAR TO DAY", t.Format("2006-01-02"))
+	case ip.Dialect == Informix && t.Nanosecond() != 0:
+		return fmt.Sprintf("DATETIME(%s) YEAR TO FRACTION(%d)", t.Format("2006-01-02 15:04:05."+strings.Repeat("0", digits)), digits)
+	case ip.Dialect == Informix:
+		return fmt.Sprintf("DATETIME(%s) YEAR TO SECOND", t.Format("2006-01-02 15:04:05"))
+	case date:
+		return fmt.Sprintf("DATE '%s'", t.Format("2006-01-02"))
+	}
+	return fmt.Sprintf("TIMESTAMP '%s'", t.Format(layout))
+}
+
+// ratScale returns the number of decimal places for *big.Rat values.
+func (ip *Interpolator) ratScale() int {
+	switch {
+	case ip.RatScale < 0:
+		return 0
+	case ip.RatScale == 0:
+		return 2
+	}
+	return ip.RatScale
+}
+
+// NoFraction is the FractionDigits setting that writes time.Time values
+// to the second.
+const NoFraction = -1
+
+// fractionDigits returns the number of fraction digits to write for
+// time.Time values.
+func (ip *Interpolator) fractionDigits() int {
+	n := ip.FractionDigits
+	switch {
+	case n < 0:
+		return 0
+	case n == 0 || n > 6:
+		n = 6
+	}
+	if ip.Dialect == Informix && n > 5 {
+		n = 5
+	}
+	return n
+}
+
+// fraction returns t truncated to the digits of a second given by
+// ip.fractionDigits, the number of those digits, and the layout of the
+// fraction for time.Format: empty if there are no digits, and without
+// trailing zeros unless ip.FixedFraction is set.
+func (ip *Interpolator) fraction(t time.Time) (time.Time, int, string) {
+	digits := ip.fractionDigits()
+	unit := time.Second
+	for i := 0; i < digits; i++ {
+		unit /= 10
+	}
+	t = t.Truncate(unit)
+	if digits == 0 {
+		return t, 0, ""
+	}
+	digit := "9"
+	if ip.FixedFraction {
+		digit = "0"
+	}
+	return t, digits, "." + strings.Repeat(digit, digits)
+}
+
+// formatBool returns b as a boolean literal. Informix has no TRUE and
+// FALSE keywords and takes 't' and 'f' instead. With BoolAsInt, b is
+// written as 1 or 0.
+func (ip *Interpolator) formatBool(b bool) string {
+	if ip.BoolAsInt {
+		if b {
+			return "1"
+		}
+		return "0"
+	}
+	if ip.Dialect == Informix {
+		if b {
+			return "'t'"
+		}
+		return "'f'"
+	}
+	return strconv.FormatBool(b)
+}
+
+// isNil reports whether rv is a nil pointer, slice or map.
+func isNil(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// byteValues returns the bytes of rv, a slice or array of a byte kind.
+func byteValues(rv reflect.Value) []byte {
+	if rv.Kind() == reflect.Slice {
+		return rv.Bytes()
+	}
+	b := make([]byte, rv.Len())
+	for i := range b {
+		b[i] = byte(rv.Index(i).Uint())
+	}
+	return b
+}
+
+// formatUUID returns b in the canonical hyphenated UUID form.
+func formatUUID(b [16]byte) string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// nullValue reports whether arg is a sql.Null[T], and if so returns its
+// V and Valid fields.
+func nullValue(arg interface{}) (v interface{}, valid bool, ok bool) {
+	t := reflect.TypeOf(arg)
+	if t.Kind() != reflect.Struct || t.PkgPath() != "database/sql" || !strings.HasPrefix(t.Name(), "Null[") {
+		return nil, false, false
+	}
+	rv := reflect.ValueOf(arg)
+	return rv.FieldByName("V").Interface(), rv.FieldByName("Valid").Bool(), true
+}
+
+// formatNumber returns n unquoted after checking that it is a valid
+// JSON number, so that no precision is lost to a float conversion.
+func formatNumber(n json.Number) (string, error) {
+	s := string(n)
+	if s == "" || (s[0] != '-' && (s[0] < '0' || s[0] > '9')) || !json.Valid([]byte(s)) {
+		return "", fmt.Errorf("invalid number %q", s)
+	}
+	return s, nil
+}
+
+// byteOrderMark is the Unicode byte order mark, U+FEFF, in UTF-8.
+const byteOrderMark = "\ufeff"
+
+// isControlChar reports whether c is a C0 control character that
+// ControlChars applies to.
+func isControlChar(c byte) bool {
+	return c < 0x20 && c != '\t' && c != '\n' && c != '\r'
+}
+
+// quoteString returns s as a string literal after applying the
+// ControlChars setting of ip.
+func (ip *Interpolator) quoteString(s string) (string, error) {
+	switch ip.ControlChars {
+	case StripControlChars:
+		s = strings.TrimPrefix(s, byteOrderMark)
+		// Bytes are kept as they are, so that invalid UTF-8 is not
+		// altered; control bytes never occur inside a multi-byte
+		// sequence
+		var b strings.Builder
+		for i := 0; i < len(s); i++ {
+			if !isControlChar(s[i]) {
+				b.WriteByte(s[i])
+			}
+		}
+		s = b.String()
+	case RejectControlChars:
+		if strings.HasPrefix(s, byteOrderMark) {
+			return "", fmt.Errorf("string starts with a byte order mark")
+		}
+		for i := 0; i < len(s); i++ {
+			if isControlChar(s[i]) {
+				return "", fmt.Errorf("string contains control character %#02x at offset %d", s[i], i)
+			}
+		}
+	}
+	return ip.escapeString(s), nil
+}
+
+// escapeString properly escapes a string for SQL using the default
+// settings.
 func escapeString(s string) string {
-	// Replace any single quotes with two single quotes (SQL escape sequence)
-	escaped := strings.ReplaceAll(s, "'", "''")
+	return defaultInterpolator().escapeString(s)
+}
+
+// mysqlEscaper escapes the characters MySQL treats specially inside
+// string literals.
+var mysqlEscaper = strings.NewReplacer(
+	"\\", "\\\\",
+	"\x00", "\\0",
+	"\n", "\\n",
+	"\r", "\\r",
+	"\x1a", "\\Z",
+)
+
+// escapeString properly escapes a string for SQL
+func (ip *Interpolator) escapeString(s string) string {
+	if ip.Dialect == MySQL {
+		if !utf8.ValidString(s) {
+			return fmt.Sprintf("X'%x'", s)
+		}
+		s = mysqlEscaper.Replace(s)
+	}
+	prefix := ""
+	if ip.extendedStrings() {
+		prefix = "E"
+	}
+	if ip.extendedStrings() || ip.QuoteEscape == BackslashQuote && ip.Dialect != MySQL {
+		s = strings.ReplaceAll(s, `\`, `\\`)
+	}
+	// Replace any single quotes with two single quotes (SQL escape
+	// sequence), or with \' if so configured
+	quote := "''"
+	if ip.QuoteEscape == BackslashQuote {
+		quote = `\'`
+	}
+	escaped := strings.ReplaceAll(s, "'", quote)
 	// Wrap in single quotes
-	return fmt.Sprintf("'%s'", escaped)
+	return fmt.Sprintf("%s'%s'", prefix, escaped)
+}
+
+// extendedStrings reports whether string literals are written as
+// E'...' escape strings.
+func (ip *Interpolator) extendedStrings() bool {
+	return ip.ExtendedStrings && ip.Dialect == Postgres
 }
 
-// formatBytes formats a byte slice as a hex string
-func formatBytes(b []byte) string {
-	return fmt.Sprintf("'\\x%x'", b)
+// QuoteBytes returns b as a binary literal for the default settings.
+// It is meant for embedding small binary values in hand built SQL.
+func QuoteBytes(b []byte) (string, error) {
+	return defaultInterpolator().QuoteBytes(b)
+}
+
+// QuoteBytes returns b as a binary literal in the Dialect and
+// BinaryEncoding of ip. It fails if b is longer than ip.MaxInlineBytes:
+// large values do not belong in the statement text and should be bound
+// as parameters (see Parameterize) or, on Informix, loaded from a file
+// with FILETOBLOB.
+func (ip *Interpolator) QuoteBytes(b []byte) (string, error) {
+	if ip.MaxInlineBytes > 0 && len(b) > ip.MaxInlineBytes {
+		return "", fmt.Errorf("%d bytes of binary data exceed the inline limit of %d, bind the value as a parameter or use FILETOBLOB instead", len(b), ip.MaxInlineBytes)
+	}
+	return ip.formatBytes(b), nil
+}
+
+// formatBytes formats a byte slice as a hex string, or as a base64
+// string when ip.BinaryEncoding is Base64Encoding. The other encodings
+// pick a hex form regardless of the dialect; HexEncoding uses the
+// dialect's own:
+//
+// Postgres gets a bytea hex escape ('\x010203'). Informix does not
+// understand that escape, so it gets the plain hex digits ('010203'),
+// which is the form Informix uses for BYTE and BLOB data in LOAD and
+// UNLOAD files. MySQL gets a standard hex string (X'010203').
+func (ip *Interpolator) formatBytes(b []byte) string {
+	switch ip.BinaryEncoding {
+	case Base64Encoding:
+		return ip.escapeString(base64.StdEncoding.EncodeToString(b))
+	case PrefixHexEncoding:
+		return fmt.Sprintf("0x%x", b)
+	case ANSIHexEncoding:
+		return fmt.Sprintf("X'%x'", b)
+	case BackslashHexEncoding:
+	default:
+		switch ip.Dialect {
+		case Informix:
+			return fmt.Sprintf("'%x'", b)
+		case MySQL:
+			return fmt.Sprintf("X'%x'", b)
+		}
+	}
+	// Written as a string, so that the backslash is escaped wherever
+	// the server reads backslash escapes
+	return ip.escapeString(fmt.Sprintf("\\x%x", b))
+}
+
+// formatArray formats a slice as a SQL array string using the default
+// settings.
+func formatArray(arr []interface{}) (string, error) {
+	return defaultInterpolator().formatArray(arr)
 }
 
 // formatArray formats a slice as a SQL array string
-func formatArray(arr []interface{}) string {
-	elements := make([]string, len(arr))
-	for i, v := range arr {
-		elements[i] = formatArgument(v)
+func (ip *Interpolator) formatArray(arr []interface{}) (string, error) {
+	return ip.formatCollection(reflect.ValueOf(arr), false)
+}
+
+// elementSizeHint is the expected length of a formatted slice element,
+// used to size the buffer of a list or collection up front.
+const elementSizeHint = 8
+
+// formatList formats the slice or array rv as a parenthesized list,
+// for use with IN.
+func (ip *Interpolator) formatList(rv reflect.Value) (string, error) {
+	if rv.Len() == 0 {
+		switch ip.EmptySlice {
+		case EmptyNull:
+			return "(NULL)", nil
+		case EmptyError:
+			return "", fmt.Errorf("%w: %s", ErrEmptySlice, rv.Type())
+		case EmptyCollection:
+			return ip.formatCollection(rv, false)
+		}
 	}
-	return fmt.Sprintf("ARRAY[%s]", strings.Join(elements, ","))
+	var b strings.Builder
+	b.Grow(2 + rv.Len()*elementSizeHint)
+	b.WriteByte('(')
+	plain := plainInteger(rv.Type().Elem())
+	for i := 0; i < rv.Len(); i++ {
+		if err := ip.checkContext(i); err != nil {
+			return "", err
+		}
+		if err := ip.checkSize(b.Len()); err != nil {
+			return "", err
+		}
+		if plain {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			writeInteger(&b, rv.Index(i))
+			continue
+		}
+		s, err := ip.formatArgument(rv.Index(i).Interface())
+		if err != nil {
+			return "", err
+		}
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(s)
+	}
+	b.WriteByte(')')
+	return b.String(), nil
+}
+
+// formatCollection formats a slice or array as a collection literal of
+// the active dialect: ARRAY[...] for Postgres and MySQL, LIST{...} for
+// Informix. Elements that are themselves slices or arrays are rendered
+// as nested collections; Postgres nests them as [...] and requires them
+// to have equal lengths. An element that is a nil slice is NULL,
+// whereas an empty one is an empty collection.
+func (ip *Interpolator) formatCollection(rv reflect.Value, nested bool) (string, error) {
+	var b strings.Builder
+	b.Grow(7 + rv.Len()*elementSizeHint)
+	if err := ip.writeCollection(&b, rv, nested); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// writeCollection writes the collection literal for rv to b. Nested
+// collections are written to the same buffer.
+func (ip *Interpolator) writeCollection(b *strings.Builder, rv reflect.Value, nested bool) error {
+	open, end := "ARRAY[", "]"
+	switch {
+	case ip.Dialect == Informix:
+		open, end = "LIST{", "}"
+	case nested:
+		open = "["
+	}
+	b.WriteString(open)
+	inner := -1
+	for i := 0; i < rv.Len(); i++ {
+		if err := ip.checkContext(i); err != nil {
+			return err
+		}
+		if err := ip.checkSize(b.Len()); err != nil {
+			return err
+		}
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		ev := rv.Index(i)
+		for ev.Kind() == reflect.Interface && !ev.IsNil() {
+			ev = ev.Elem()
+		}
+		if (ev.Kind() == reflect.Slice || ev.Kind() == reflect.Ptr) && ev.IsNil() {
+			b.WriteString("NULL")
+			continue
+		}
+		if ev.IsValid() && plainInteger(ev.Type()) {
+			writeInteger(b, ev)
+			continue
+		}
+		if !isCollection(ev) {
+			s, err := ip.formatArgument(ev.Interface())
+			if err != nil {
+				return err
+			}
+			b.WriteString(s)
+			continue
+		}
+		if ip.Dialect != Informix {
+			if inner >= 0 && ev.Len() != inner {
+				return fmt.Errorf("jagged array: element %d has length %d, want %d", i, ev.Len(), inner)
+			}
+			inner = ev.Len()
+		}
+		if err := ip.writeCollection(b, ev, true); err != nil {
+			return err
+		}
+	}
+	b.WriteString(end)
+	return nil
+}
+
+// plainInteger reports whether t is a built-in integer type with no
+// registered formatter, whose values are always written as plain
+// decimal numbers.
+func plainInteger(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return t.PkgPath() == "" && lookupFormatter(t) == nil
+	}
+	return false
+}
+
+// writeInteger writes the integer v to b in decimal, without the
+// allocations of formatArgument.
+func writeInteger(b *strings.Builder, v reflect.Value) {
+	var buf [20]byte
+	if v.CanInt() {
+		b.Write(strconv.AppendInt(buf[:0], v.Int(), 10))
+	} else {
+		b.Write(strconv.AppendUint(buf[:0], v.Uint(), 10))
+	}
+}
+
+// hasNestedCollection reports whether any element of the slice rv is
+// itself rendered as a collection.
+func hasNestedCollection(rv reflect.Value) bool {
+	for i := 0; i < rv.Len(); i++ {
+		ev := rv.Index(i)
+		for ev.Kind() == reflect.Interface && !ev.IsNil() {
+			ev = ev.Elem()
+		}
+		if isCollection(ev) {
+			return true
+		}
+	}
+	return false
+}
+
+// isCollection reports whether v is an array or a non-nil slice with no
+// more specific rendering: byte slices and arrays, registered types,
+// and types with their own SQL or text form are formatted as scalars.
+func isCollection(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice:
+		if v.IsNil() {
+			return false
+		}
+	case reflect.Array:
+	default:
+		return false
+	}
+	if v.Type().Elem().Kind() == reflect.Uint8 {
+		return false
+	}
+	if lookupFormatter(v.Type()) != nil {
+		return false
+	}
+	switch v.Interface().(type) {
+	case Runes, Tuples, driver.Valuer, encoding.TextMarshaler, encoding.BinaryMarshaler, fmt.Stringer:
+		return false
+	}
+	return true
+}
+
+// Format returns v rendered as a SQL literal, exactly as InterpolateQuery
+// would render it as an argument.
+func Format[T any](v T) (string, error) {
+	return formatArgument(v)
+}
+
+// SanitizeValue returns v rendered as a safe SQL literal using the
+// default settings. It is the primitive InterpolateQuery and the other
+// functions of this package use for every argument, for callers that
+// build SQL without placeholders.
+func SanitizeValue(v interface{}) (string, error) {
+	return defaultInterpolator().SanitizeValue(v)
+}
+
+// SanitizeValue is like the package level SanitizeValue, but renders v
+// using the settings of ip.
+func (ip *Interpolator) SanitizeValue(v interface{}) (string, error) {
+	return ip.formatArgument(v)
 }
@@ -1,7 +1,17 @@
 package informix
 
 import (
+	"context"
+	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net"
+	"reflect"
+	"sync"
 	"testing"
 	"time"
 )
@@ -15,6 +25,20 @@ func (c customValuer) Value() (driver.Value, error) {
 	return c.value, nil
 }
 
+// valuerFunc is a function that implements driver.Valuer.
+type valuerFunc func() (driver.Value, error)
+
+func (f valuerFunc) Value() (driver.Value, error) {
+	return f()
+}
+
+// cyclicValuer is a driver.Valuer that returns itself.
+type cyclicValuer struct{}
+
+func (c cyclicValuer) Value() (driver.Value, error) {
+	return c, nil
+}
+
 func TestInterpolateQuery(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -111,157 +135,3456 @@ func TestInterpolateQuery(t *testing.T) {
 	}
 }
 
-func TestFormatArgument(t *testing.T) {
-	timeValue := time.Date(2024, 2, 12, 15, 4, 5, 999999000, time.UTC)
-
+func TestInterpolateQueryDetailed(t *testing.T) {
 	tests := []struct {
 		name     string
-		arg      interface{}
+		query    string
+		args     []interface{}
 		expected string
+		used     []int
+		wantErr  bool
 	}{
 		{
-			name:     "nil value",
-			arg:      nil,
-			expected: "NULL",
+			name:     "all used",
+			query:    "SELECT * FROM users WHERE id = $1 AND name = $2",
+			args:     []interface{}{123, "John"},
+			expected: "SELECT * FROM users WHERE id = 123 AND name = 'John'",
+			used:     []int{0, 1},
 		},
 		{
-			name:     "string",
-			arg:      "hello",
-			expected: "'hello'",
+			name:     "reuse",
+			query:    "SELECT * FROM users WHERE first = $1 OR last = $1 OR id = $2",
+			args:     []interface{}{"John", 7},
+			expected: "SELECT * FROM users WHERE first = 'John' OR last = 'John' OR id = 7",
+			used:     []int{0, 1},
 		},
 		{
-			name:     "int",
-			arg:      42,
-			expected: "42",
+			name:     "out of order",
+			query:    "SELECT * FROM users WHERE name = $2 AND id = $1",
+			args:     []interface{}{123, "John"},
+			expected: "SELECT * FROM users WHERE name = 'John' AND id = 123",
+			used:     []int{0, 1},
 		},
 		{
-			name:     "bool true",
-			arg:      true,
-			expected: "true",
+			name:     "gap",
+			query:    "SELECT * FROM users WHERE id = $1 AND name = $3",
+			args:     []interface{}{123, "unused", "John"},
+			expected: "SELECT * FROM users WHERE id = 123 AND name = 'John'",
+			used:     []int{0, 2},
 		},
 		{
-			name:     "bool false",
-			arg:      false,
-			expected: "false",
+			name:     "reuse with extra argument",
+			query:    "SELECT * FROM users WHERE first = $1 OR last = $1",
+			args:     []interface{}{"John", 7},
+			expected: "SELECT * FROM users WHERE first = 'John' OR last = 'John'",
+			used:     []int{0},
 		},
 		{
-			name:     "float64",
-			arg:      3.14,
-			expected: "3.140000",
+			name:     "reuse with exact count",
+			query:    "SELECT * FROM users WHERE first = $2 OR last = $2 OR id = $1 OR parent = $1",
+			args:     []interface{}{7, "John"},
+			expected: "SELECT * FROM users WHERE first = 'John' OR last = 'John' OR id = 7 OR parent = 7",
+			used:     []int{0, 1},
 		},
 		{
-			name:     "time.Time",
-			arg:      timeValue,
-			expected: "'2024-02-12 15:04:05.999999'",
+			name:     "question marks",
+			query:    "SELECT * FROM users WHERE id = ? AND name = ?",
+			args:     []interface{}{123, "John"},
+			expected: "SELECT * FROM users WHERE id = 123 AND name = 'John'",
+			used:     []int{0, 1},
 		},
 		{
-			name:     "[]byte",
-			arg:      []byte{0x1, 0x2, 0x3},
-			expected: "'\\x010203'",
+			name:     "too many",
+			query:    "SELECT * FROM users WHERE id = $1",
+			args:     []interface{}{123, "John"},
+			expected: "SELECT * FROM users WHERE id = 123",
+			used:     []int{0},
 		},
 		{
-			name:     "custom valuer",
-			arg:      customValuer{value: "custom"},
-			expected: "'custom'",
+			name:    "unsupported argument",
+			query:   "SELECT * FROM users WHERE id = $1 AND name = $2",
+			args:    []interface{}{123, complex(1, 2)},
+			wantErr: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := formatArgument(tt.arg)
+			got, used, err := InterpolateQueryDetailed(tt.query, tt.args...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("InterpolateQueryDetailed() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
 			if got != tt.expected {
-				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+				t.Errorf("InterpolateQueryDetailed() = %v, want %v", got, tt.expected)
+			}
+			if !reflect.DeepEqual(used, tt.used) {
+				t.Errorf("InterpolateQueryDetailed() used = %v, want %v", used, tt.used)
 			}
 		})
 	}
 }
 
-func TestEscapeString(t *testing.T) {
+func TestInterpolateQueryMixedStyles(t *testing.T) {
 	tests := []struct {
 		name     string
-		input    string
+		allow    bool
+		query    string
+		args     []interface{}
 		expected string
+		wantErr  bool
 	}{
 		{
-			name:     "normal string",
-			input:    "hello",
-			expected: "'hello'",
+			name:    "mixed",
+			query:   "SELECT * FROM t WHERE a = ? AND b = $1",
+			args:    []interface{}{1},
+			wantErr: true,
 		},
 		{
-			name:     "string with single quote",
-			input:    "O'Connor",
-			expected: "'O''Connor'",
+			name:     "mixed allowed",
+			allow:    true,
+			query:    "SELECT * FROM t WHERE a = ? AND b = $2",
+			args:     []interface{}{1, 2},
+			expected: "SELECT * FROM t WHERE a = 1 AND b = 2",
 		},
 		{
-			name:     "string with multiple quotes",
-			input:    "It's O'Connor's",
-			expected: "'It''s O''Connor''s'",
+			name:     "question marks only",
+			query:    "SELECT * FROM t WHERE a = ? AND b = ?",
+			args:     []interface{}{1, 2},
+			expected: "SELECT * FROM t WHERE a = 1 AND b = 2",
 		},
 		{
-			name:     "empty string",
-			input:    "",
-			expected: "''",
+			name:     "dollars only",
+			query:    "SELECT * FROM t WHERE a = $1 AND b = $2",
+			args:     []interface{}{1, 2},
+			expected: "SELECT * FROM t WHERE a = 1 AND b = 2",
+		},
+		{
+			name:     "question mark in literal",
+			query:    "SELECT * FROM t WHERE a = '?' AND b = $1",
+			args:     []interface{}{1},
+			expected: "SELECT * FROM t WHERE a = '?' AND b = 1",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := escapeString(tt.input)
+			ip := &Interpolator{AllowMixedStyles: tt.allow}
+			got, err := ip.InterpolateQuery(tt.query, tt.args...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("InterpolateQuery() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
 			if got != tt.expected {
-				t.Errorf("escapeString() = %v, want %v", got, tt.expected)
+				t.Errorf("InterpolateQuery() = %v, want %v", got, tt.expected)
 			}
 		})
 	}
 }
 
-func TestFormatArray(t *testing.T) {
+// cancelingValuer cancels a context when its value is taken.
+type cancelingValuer struct {
+	cancel context.CancelFunc
+}
+
+func (c cancelingValuer) Value() (driver.Value, error) {
+	c.cancel()
+	return int64(0), nil
+}
+
+func TestInterpolateQueryContext(t *testing.T) {
+	got, err := InterpolateQueryContext(context.Background(), "SELECT * FROM t WHERE id IN $1", []int{1, 2, 3})
+	if err != nil {
+		t.Fatalf("InterpolateQueryContext() error = %v", err)
+	}
+	if expected := "SELECT * FROM t WHERE id IN (1,2,3)"; got != expected {
+		t.Errorf("InterpolateQueryContext() = %v, want %v", got, expected)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	large := make([]interface{}, 100000)
+	for i := range large {
+		large[i] = i
+	}
+	// Cancel part way through formatting the slice.
+	large[5000] = cancelingValuer{cancel: cancel}
+	_, err = InterpolateQueryContext(ctx, "SELECT * FROM t WHERE id IN $1", large)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("InterpolateQueryContext() error = %v, want %v", err, context.Canceled)
+	}
+
+	ints := make([]int, 100000)
+	_, err = InterpolateQueryContext(ctx, "SELECT * FROM t WHERE id IN $1", ints)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("InterpolateQueryContext() with canceled context error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestInterpolateQueryMaxQuerySize(t *testing.T) {
+	ip := &Interpolator{MaxQuerySize: 1024}
+	got, err := ip.InterpolateQuery("SELECT * FROM t WHERE id IN $1", []int{1, 2, 3})
+	if err != nil {
+		t.Fatalf("InterpolateQuery() error = %v", err)
+	}
+	if expected := "SELECT * FROM t WHERE id IN (1,2,3)"; got != expected {
+		t.Errorf("InterpolateQuery() = %v, want %v", got, expected)
+	}
+
+	large := make([]interface{}, 100000)
+	for i := range large {
+		large[i] = "value"
+	}
+	// Building must stop long before the end of the slice.
+	large[len(large)-1] = valuerFunc(func() (driver.Value, error) {
+		t.Errorf("InterpolateQuery() formatted the whole slice")
+		return nil, nil
+	})
+	_, err = ip.InterpolateQuery("SELECT * FROM t WHERE name IN $1", large)
+	if !errors.Is(err, ErrQueryTooLarge) {
+		t.Errorf("InterpolateQuery() error = %v, want %v", err, ErrQueryTooLarge)
+	}
+
+	ints := make([]int, 100000)
+	_, err = ip.InterpolateQuery("SELECT * FROM t WHERE id = ANY($1)", &ints)
+	if !errors.Is(err, ErrQueryTooLarge) {
+		t.Errorf("InterpolateQuery() with collection error = %v, want %v", err, ErrQueryTooLarge)
+	}
+
+	_, err = ip.InterpolateQuery("SELECT $1, $2", string(make([]byte, 600)), string(make([]byte, 600)))
+	if !errors.Is(err, ErrQueryTooLarge) {
+		t.Errorf("InterpolateQuery() with long strings error = %v, want %v", err, ErrQueryTooLarge)
+	}
+}
+
+func TestInterpolateQueryNoPartialOutput(t *testing.T) {
+	formatted := 0
+	counted := valuerFunc(func() (driver.Value, error) {
+		formatted++
+		return "x", nil
+	})
+
+	tests := []struct {
+		name    string
+		ip      *Interpolator
+		query   string
+		args    []interface{}
+		wantErr error
+	}{
+		{
+			name:    "missing argument after valid ones",
+			ip:      &Interpolator{StrictArgs: true},
+			query:   "SELECT $1, $2, $3",
+			args:    []interface{}{counted, counted},
+			wantErr: ErrTooFewArgs,
+		},
+		{
+			name:    "unused argument",
+			ip:      &Interpolator{},
+			query:   "SELECT $1, $3",
+			args:    []interface{}{counted, counted, counted},
+			wantErr: ErrTooManyArgs,
+		},
+		{
+			name:    "bad value after valid ones",
+			ip:      &Interpolator{},
+			query:   "SELECT $1, $2",
+			args:    []interface{}{"ok", complex(1, 2)},
+			wantErr: ErrUnsupportedType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatted = 0
+			got, err := tt.ip.InterpolateQuery(tt.query, tt.args...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("InterpolateQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != "" {
+				t.Errorf("InterpolateQuery() = %q with an error, want \"\"", got)
+			}
+			if formatted != 0 {
+				t.Errorf("InterpolateQuery() formatted %d arguments before failing, want 0", formatted)
+			}
+		})
+	}
+}
+
+func TestInterpolateQueryConcurrent(t *testing.T) {
+	query := "SELECT * FROM users WHERE id IN $1 AND name = $2"
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				name := fmt.Sprintf("user%d_%d", g, i)
+				got, err := InterpolateQuery(query, []int{g, i}, name)
+				if err != nil {
+					t.Errorf("InterpolateQuery() error = %v", err)
+					return
+				}
+				expected := fmt.Sprintf("SELECT * FROM users WHERE id IN (%d,%d) AND name = '%s'", g, i, name)
+				if got != expected {
+					t.Errorf("InterpolateQuery() = %v, want %v", got, expected)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+}
+
+func TestMustInterpolateQuery(t *testing.T) {
+	got := MustInterpolateQuery("SELECT * FROM users WHERE id = $1", 123)
+	if expected := "SELECT * FROM users WHERE id = 123"; got != expected {
+		t.Errorf("MustInterpolateQuery() = %v, want %v", got, expected)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MustInterpolateQuery() with too many arguments did not panic")
+		}
+	}()
+	MustInterpolateQuery("SELECT * FROM users WHERE id = $1", 123, "extra")
+}
+
+func TestInterpolateQueryStrictArgs(t *testing.T) {
 	tests := []struct {
 		name     string
-		input    []interface{}
+		strict   bool
+		query    string
+		args     []interface{}
 		expected string
+		wantErr  error
 	}{
 		{
-			name:     "string array",
-			input:    []interface{}{"a", "b", "c"},
-			expected: "ARRAY['a','b','c']",
+			name:     "lenient too few",
+			query:    "SELECT * FROM users WHERE id = $1 AND name = $2",
+			args:     []interface{}{123},
+			expected: "SELECT * FROM users WHERE id = 123 AND name = $2",
 		},
 		{
-			name:     "mixed array",
-			input:    []interface{}{1, "two", true},
-			expected: "ARRAY[1,'two',true]",
+			name:    "strict too few",
+			strict:  true,
+			query:   "SELECT * FROM users WHERE id = $1 AND name = $2",
+			args:    []interface{}{123},
+			wantErr: ErrTooFewArgs,
 		},
 		{
-			name:     "empty array",
-			input:    []interface{}{},
-			expected: "ARRAY[]",
+			name:    "strict no arguments",
+			strict:  true,
+			query:   "SELECT * FROM users WHERE id = ?",
+			wantErr: ErrTooFewArgs,
 		},
 		{
-			name:     "array with null",
-			input:    []interface{}{nil, "value", nil},
-			expected: "ARRAY[NULL,'value',NULL]",
+			name:     "strict exact",
+			strict:   true,
+			query:    "SELECT * FROM users WHERE id = $1 AND name = $2",
+			args:     []interface{}{123, "John"},
+			expected: "SELECT * FROM users WHERE id = 123 AND name = 'John'",
+		},
+		{
+			name:    "strict too many",
+			strict:  true,
+			query:   "SELECT * FROM users WHERE id = $1",
+			args:    []interface{}{123, "John"},
+			wantErr: ErrTooManyArgs,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := formatArray(tt.input)
+			ip := &Interpolator{StrictArgs: tt.strict}
+			got, err := ip.InterpolateQuery(tt.query, tt.args...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("InterpolateQuery() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
 			if got != tt.expected {
-				t.Errorf("formatArray() = %v, want %v", got, tt.expected)
+				t.Errorf("InterpolateQuery() = %v, want %v", got, tt.expected)
 			}
 		})
 	}
 }
 
-// Benchmark the main function
-func BenchmarkInterpolateQuery(b *testing.B) {
-	query := "SELECT * FROM users WHERE id = $1 AND name = $2 AND active = $3"
-	args := []interface{}{123, "John", true}
+func TestInterpolateQueryPlaceholderBase(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     int
+		strict   bool
+		query    string
+		args     []interface{}
+		expected string
+		wantErr  error
+	}{
+		{
+			name:     "zero based",
+			base:     ZeroBase,
+			query:    "SELECT * FROM t WHERE a = $0 AND b = $1 OR c = $0",
+			args:     []interface{}{1, "x"},
+			expected: "SELECT * FROM t WHERE a = 1 AND b = 'x' OR c = 1",
+		},
+		{
+			name:     "one based",
+			query:    "SELECT * FROM t WHERE a = $1 AND b = $2",
+			args:     []interface{}{1, "x"},
+			expected: "SELECT * FROM t WHERE a = 1 AND b = 'x'",
+		},
+		{
+			name:    "zero based out of range",
+			base:    ZeroBase,
+			strict:  true,
+			query:   "SELECT * FROM t WHERE a = $0 AND b = $2",
+			args:    []interface{}{1, "x"},
+			wantErr: ErrTooFewArgs,
+		},
+		{
+			name:    "one based out of range",
+			strict:  true,
+			query:   "SELECT * FROM t WHERE a = $0",
+			args:    []interface{}{1},
+			wantErr: ErrTooFewArgs,
+		},
+		{
+			name:    "zero based too many",
+			base:    ZeroBase,
+			query:   "SELECT * FROM t WHERE a = $0",
+			args:    []interface{}{1, 2},
+			wantErr: ErrTooManyArgs,
+		},
+		{
+			name:     "custom base",
+			base:     10,
+			query:    "SELECT * FROM t WHERE a = $10 AND b = $11 OR c = $10",
+			args:     []interface{}{1, "x"},
+			expected: "SELECT * FROM t WHERE a = 1 AND b = 'x' OR c = 1",
+		},
+		{
+			name:    "custom base below range",
+			base:    10,
+			strict:  true,
+			query:   "SELECT * FROM t WHERE a = $9 AND b = $10",
+			args:    []interface{}{1},
+			wantErr: ErrTooFewArgs,
+		},
+		{
+			name:    "custom base above range",
+			base:    10,
+			strict:  true,
+			query:   "SELECT * FROM t WHERE a = $10 AND b = $12",
+			args:    []interface{}{1, 2},
+			wantErr: ErrTooFewArgs,
+		},
+		{
+			name:     "question marks are unaffected",
+			base:     ZeroBase,
+			query:    "SELECT * FROM t WHERE a = ? AND b = ?",
+			args:     []interface{}{1, 2},
+			expected: "SELECT * FROM t WHERE a = 1 AND b = 2",
+		},
+	}
 
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		_, err := InterpolateQuery(query, args...)
-		if err != nil {
-			b.Fatal(err)
-		}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip := &Interpolator{PlaceholderBase: tt.base, StrictArgs: tt.strict}
+			got, err := ip.InterpolateQuery(tt.query, tt.args...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("InterpolateQuery() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.expected {
+				t.Errorf("InterpolateQuery() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNegativePlaceholderBase(t *testing.T) {
+	ip := &Interpolator{PlaceholderBase: -2}
+	query := "SELECT * FROM t WHERE a = $0"
+
+	if _, err := ip.InterpolateQuery(query, 1); err == nil {
+		t.Errorf("InterpolateQuery() succeeded with PlaceholderBase -2")
+	}
+	if _, _, err := ip.Parameterize(query, 1); err == nil {
+		t.Errorf("Parameterize() succeeded with PlaceholderBase -2")
+	}
+	if err := ip.ValidateQuery(query); err == nil {
+		t.Errorf("ValidateQuery() succeeded with PlaceholderBase -2")
+	}
+}
+
+func TestSetDefaultDialect(t *testing.T) {
+	defer SetDefaultDialect(DefaultDialect())
+
+	if got := DefaultDialect(); got != Postgres {
+		t.Fatalf("DefaultDialect() = %v, want %v", got, Postgres)
+	}
+	query := "SELECT ?, ?"
+	args := []interface{}{true, []byte{0xca, 0xfe}}
+
+	got, err := InterpolateQuery(query, args...)
+	if err != nil {
+		t.Fatalf("InterpolateQuery() error = %v", err)
+	}
+	if want := `SELECT true, '\xcafe'`; got != want {
+		t.Errorf("InterpolateQuery() = %v, want %v", got, want)
+	}
+
+	SetDefaultDialect(Informix)
+	if got := DefaultDialect(); got != Informix {
+		t.Fatalf("DefaultDialect() = %v, want %v", got, Informix)
+	}
+	got, err = InterpolateQuery(query, args...)
+	if err != nil {
+		t.Fatalf("InterpolateQuery() error = %v", err)
+	}
+	if want := `SELECT 't', 'cafe'`; got != want {
+		t.Errorf("InterpolateQuery() = %v, want %v", got, want)
 	}
 }
+
+func TestFormatArgument(t *testing.T) {
+	timeValue := time.Date(2024, 2, 12, 15, 4, 5, 999999000, time.UTC)
+
+	tests := []struct {
+		name     string
+		arg      interface{}
+		expected string
+	}{
+		{
+			name:     "nil value",
+			arg:      nil,
+			expected: "NULL",
+		},
+		{
+			name:     "string",
+			arg:      "hello",
+			expected: "'hello'",
+		},
+		{
+			name:     "int",
+			arg:      42,
+			expected: "42",
+		},
+		{
+			name:     "bool true",
+			arg:      true,
+			expected: "true",
+		},
+		{
+			name:     "bool false",
+			arg:      false,
+			expected: "false",
+		},
+		{
+			name:     "float64",
+			arg:      3.14,
+			expected: "3.140000",
+		},
+		{
+			name:     "time.Time",
+			arg:      timeValue,
+			expected: "'2024-02-12 15:04:05.999999'",
+		},
+		{
+			name:     "[]byte",
+			arg:      []byte{0x1, 0x2, 0x3},
+			expected: "'\\x010203'",
+		},
+		{
+			name:     "custom valuer",
+			arg:      customValuer{value: "custom"},
+			expected: "'custom'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatArgument(tt.arg)
+			if err != nil {
+				t.Fatalf("formatArgument() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSanitizeValue(t *testing.T) {
+	timeValue := time.Date(2024, 2, 12, 15, 4, 5, 999999000, time.UTC)
+
+	tests := []struct {
+		name     string
+		dialect  Dialect
+		arg      interface{}
+		expected string
+		wantErr  error
+	}{
+		{
+			name:     "nil value",
+			arg:      nil,
+			expected: "NULL",
+		},
+		{
+			name:     "string",
+			arg:      "it's",
+			expected: "'it''s'",
+		},
+		{
+			name:     "int",
+			arg:      42,
+			expected: "42",
+		},
+		{
+			name:     "bool",
+			arg:      true,
+			expected: "true",
+		},
+		{
+			name:     "informix bool",
+			dialect:  Informix,
+			arg:      true,
+			expected: "'t'",
+		},
+		{
+			name:     "float64",
+			arg:      3.14,
+			expected: "3.140000",
+		},
+		{
+			name:     "time.Time",
+			arg:      timeValue,
+			expected: "'2024-02-12 15:04:05.999999'",
+		},
+		{
+			name:     "[]byte",
+			arg:      []byte{0x1, 0x2, 0x3},
+			expected: "'\\x010203'",
+		},
+		{
+			name:     "mysql []byte",
+			dialect:  MySQL,
+			arg:      []byte{0x1, 0x2, 0x3},
+			expected: "X'010203'",
+		},
+		{
+			name:     "custom valuer",
+			arg:      customValuer{value: "custom"},
+			expected: "'custom'",
+		},
+		{
+			name:    "complex",
+			arg:     1 + 2i,
+			wantErr: ErrUnsupportedType,
+		},
+		{
+			name:    "map with unsupported keys",
+			arg:     map[[2]int]string{{1, 2}: "x"},
+			wantErr: ErrUnsupportedType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip := &Interpolator{Dialect: tt.dialect}
+			got, err := ip.SanitizeValue(tt.arg)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SanitizeValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("SanitizeValue() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+
+	if got, err := SanitizeValue("x"); err != nil || got != "'x'" {
+		t.Errorf("SanitizeValue() = %v, %v, want 'x'", got, err)
+	}
+}
+
+func TestFormatPercentSigns(t *testing.T) {
+	const data = "100%% done %s %v %d"
+	type label string
+
+	tests := []struct {
+		name     string
+		arg      interface{}
+		expected string
+	}{
+		{
+			name:     "string",
+			arg:      data,
+			expected: "'100%% done %s %v %d'",
+		},
+		{
+			name:     "named string",
+			arg:      label(data),
+			expected: "'100%% done %s %v %d'",
+		},
+		{
+			name:     "text",
+			arg:      Text(data),
+			expected: "'100%% done %s %v %d'",
+		},
+		{
+			name:     "like value",
+			arg:      LikeValue(data),
+			expected: `'100\%\% done \%s \%v \%d'`,
+		},
+		{
+			name:     "column",
+			arg:      Column(data),
+			expected: `"100%% done %s %v %d"`,
+		},
+		{
+			name:     "json",
+			arg:      map[string]string{data: data},
+			expected: `'{"100%% done %s %v %d":"100%% done %s %v %d"}'`,
+		},
+		{
+			name:     "slice",
+			arg:      []string{data, "%"},
+			expected: "('100%% done %s %v %d','%')",
+		},
+		{
+			name:     "stringer",
+			arg:      customStringer(data),
+			expected: "'100%% done %s %v %d'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatArgument(tt.arg)
+			if err != nil {
+				t.Fatalf("formatArgument() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+
+	got, err := InterpolateQuery("SELECT * FROM t WHERE a LIKE '%s%' AND b = ?", data)
+	if err != nil {
+		t.Fatalf("InterpolateQuery() error = %v", err)
+	}
+	if want := "SELECT * FROM t WHERE a LIKE '%s%' AND b = '100%% done %s %v %d'"; got != want {
+		t.Errorf("InterpolateQuery() = %v, want %v", got, want)
+	}
+}
+
+// customStringer implements only fmt.Stringer, returning itself.
+type customStringer string
+
+func (s customStringer) String() string {
+	return string(s)
+}
+
+// uuid mirrors github.com/google/uuid.UUID.
+type uuid [16]byte
+
+func (u uuid) String() string {
+	return formatUUID(u)
+}
+
+func (u uuid) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// color implements only fmt.Stringer.
+type color int
+
+func (c color) String() string {
+	return [...]string{"red", "green"}[c]
+}
+
+// badText fails to marshal.
+type badText struct{}
+
+func (badText) MarshalText() ([]byte, error) {
+	return nil, errors.New("cannot marshal")
+}
+
+func TestFormatTimeLocation(t *testing.T) {
+	plus2 := time.FixedZone("UTC+2", 2*60*60)
+	minus5 := time.FixedZone("UTC-5", -5*60*60)
+
+	tests := []struct {
+		name     string
+		loc      *time.Location
+		arg      time.Time
+		expected string
+	}{
+		{
+			name:     "own location",
+			arg:      time.Date(2024, 2, 12, 15, 4, 5, 0, plus2),
+			expected: "'2024-02-12 15:04:05'",
+		},
+		{
+			name:     "utc to positive offset",
+			loc:      plus2,
+			arg:      time.Date(2024, 2, 12, 23, 30, 0, 0, time.UTC),
+			expected: "'2024-02-13 01:30:00'",
+		},
+		{
+			name:     "utc to negative offset",
+			loc:      minus5,
+			arg:      time.Date(2024, 1, 1, 2, 0, 0, 500000000, time.UTC),
+			expected: "'2023-12-31 21:00:00.5'",
+		},
+		{
+			name:     "zero time is not converted",
+			loc:      minus5,
+			arg:      time.Time{},
+			expected: "'0001-01-01 00:00:00'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip := &Interpolator{Location: tt.loc}
+			got, err := ip.formatArgument(tt.arg)
+			if err != nil {
+				t.Fatalf("formatArgument() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatTypedTimeLiterals(t *testing.T) {
+	date := time.Date(2024, 2, 12, 0, 0, 0, 0, time.UTC)
+	full := time.Date(2024, 2, 12, 15, 4, 5, 0, time.UTC)
+	frac := time.Date(2024, 2, 12, 15, 4, 5, 123456789, time.UTC)
+
+	tests := []struct {
+		name     string
+		dialect  Dialect
+		midnight bool
+		arg      time.Time
+		expected string
+	}{
+		{
+			name:     "postgres timestamp",
+			arg:      full,
+			expected: "TIMESTAMP '2024-02-12 15:04:05'",
+		},
+		{
+			name:     "postgres fraction",
+			arg:      frac,
+			expected: "TIMESTAMP '2024-02-12 15:04:05.123456'",
+		},
+		{
+			name:     "postgres midnight without option",
+			arg:      date,
+			expected: "TIMESTAMP '2024-02-12 00:00:00'",
+		},
+		{
+			name:     "postgres date",
+			midnight: true,
+			arg:      date,
+			expected: "DATE '2024-02-12'",
+		},
+		{
+			name:     "postgres timestamp with midnight option",
+			midnight: true,
+			arg:      full,
+			expected: "TIMESTAMP '2024-02-12 15:04:05'",
+		},
+		{
+			name:     "informix datetime",
+			dialect:  Informix,
+			arg:      full,
+			expected: "DATETIME(2024-02-12 15:04:05) YEAR TO SECOND",
+		},
+		{
+			name:     "informix fraction",
+			dialect:  Informix,
+			arg:      frac,
+			expected: "DATETIME(2024-02-12 15:04:05.12345) YEAR TO FRACTION(5)",
+		},
+		{
+			name:     "informix date",
+			dialect:  Informix,
+			midnight: true,
+			arg:      date,
+			expected: "DATETIME(2024-02-12) YEAR TO DAY",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip := &Interpolator{Dialect: tt.dialect, TypedTimeLiterals: true, MidnightAsDate: tt.midnight}
+			got, err := ip.formatArgument(tt.arg)
+			if err != nil {
+				t.Fatalf("formatArgument() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatTimeFixedFraction(t *testing.T) {
+	tenth := time.Date(2024, 2, 12, 15, 4, 5, 100000000, time.UTC)
+	whole := time.Date(2024, 2, 12, 15, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		fixed    bool
+		typed    bool
+		arg      time.Time
+		expected string
+	}{
+		{
+			name:     "trimmed",
+			arg:      tenth,
+			expected: "'2024-02-12 15:04:05.1'",
+		},
+		{
+			name:     "fixed",
+			fixed:    true,
+			arg:      tenth,
+			expected: "'2024-02-12 15:04:05.100000'",
+		},
+		{
+			name:     "trimmed whole second",
+			arg:      whole,
+			expected: "'2024-02-12 15:04:05'",
+		},
+		{
+			name:     "fixed whole second",
+			fixed:    true,
+			arg:      whole,
+			expected: "'2024-02-12 15:04:05.000000'",
+		},
+		{
+			name:     "fixed typed",
+			fixed:    true,
+			typed:    true,
+			arg:      tenth,
+			expected: "TIMESTAMP '2024-02-12 15:04:05.100000'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip := &Interpolator{FixedFraction: tt.fixed, TypedTimeLiterals: tt.typed}
+			got, err := ip.formatArgument(tt.arg)
+			if err != nil {
+				t.Fatalf("formatArgument() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatTimeFractionDigits(t *testing.T) {
+	tm := time.Date(2024, 2, 12, 15, 4, 5, 123456789, time.UTC)
+	short := time.Date(2024, 2, 12, 15, 4, 5, 999, time.UTC)
+
+	tests := []struct {
+		name     string
+		ip       *Interpolator
+		arg      time.Time
+		expected string
+	}{
+		{
+			name:     "default",
+			ip:       &Interpolator{},
+			arg:      tm,
+			expected: "'2024-02-12 15:04:05.123456'",
+		},
+		{
+			name:     "no fraction",
+			ip:       &Interpolator{FractionDigits: NoFraction},
+			arg:      tm,
+			expected: "'2024-02-12 15:04:05'",
+		},
+		{
+			name:     "three digits",
+			ip:       &Interpolator{FractionDigits: 3},
+			arg:      tm,
+			expected: "'2024-02-12 15:04:05.123'",
+		},
+		{
+			name:     "five digits",
+			ip:       &Interpolator{FractionDigits: 5},
+			arg:      tm,
+			expected: "'2024-02-12 15:04:05.12345'",
+		},
+		{
+			name:     "truncated not rounded",
+			ip:       &Interpolator{FractionDigits: 2},
+			arg:      time.Date(2024, 2, 12, 15, 4, 5, 999000000, time.UTC),
+			expected: "'2024-02-12 15:04:05.99'",
+		},
+		{
+			name:     "fixed three digits",
+			ip:       &Interpolator{FractionDigits: 3, FixedFraction: true},
+			arg:      short,
+			expected: "'2024-02-12 15:04:05.000'",
+		},
+		{
+			name:     "informix default",
+			ip:       &Interpolator{Dialect: Informix},
+			arg:      tm,
+			expected: "'2024-02-12 15:04:05.12345'",
+		},
+		{
+			name:     "informix clamped",
+			ip:       &Interpolator{Dialect: Informix, FractionDigits: 6},
+			arg:      tm,
+			expected: "'2024-02-12 15:04:05.12345'",
+		},
+		{
+			name:     "informix typed three digits",
+			ip:       &Interpolator{Dialect: Informix, TypedTimeLiterals: true, FractionDigits: 3},
+			arg:      tm,
+			expected: "DATETIME(2024-02-12 15:04:05.123) YEAR TO FRACTION(3)",
+		},
+		{
+			name:     "informix typed fraction truncated away",
+			ip:       &Interpolator{Dialect: Informix, TypedTimeLiterals: true, FractionDigits: 3},
+			arg:      short,
+			expected: "DATETIME(2024-02-12 15:04:05) YEAR TO SECOND",
+		},
+		{
+			name:     "informix typed no fraction",
+			ip:       &Interpolator{Dialect: Informix, TypedTimeLiterals: true, FractionDigits: NoFraction},
+			arg:      tm,
+			expected: "DATETIME(2024-02-12 15:04:05) YEAR TO SECOND",
+		},
+		{
+			name:     "typed no fraction",
+			ip:       &Interpolator{TypedTimeLiterals: true, FractionDigits: NoFraction},
+			arg:      tm,
+			expected: "TIMESTAMP '2024-02-12 15:04:05'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.ip.formatArgument(tt.arg)
+			if err != nil {
+				t.Fatalf("formatArgument() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatTimePreset(t *testing.T) {
+	tm := time.Date(2024, 2, 12, 15, 4, 5, 123456789, time.UTC)
+	east := time.Date(2024, 2, 12, 15, 4, 5, 0, time.FixedZone("", 2*60*60))
+
+	tests := []struct {
+		name     string
+		ip       *Interpolator
+		arg      time.Time
+		expected string
+	}{
+		{
+			name:     "default",
+			ip:       &Interpolator{},
+			arg:      tm,
+			expected: "'2024-02-12 15:04:05.123456'",
+		},
+		{
+			name:     "informix second",
+			ip:       &Interpolator{TimePreset: TimePresetInformixSecond},
+			arg:      tm,
+			expected: "'2024-02-12 15:04:05'",
+		},
+		{
+			name:     "informix fraction 5",
+			ip:       &Interpolator{TimePreset: TimePresetInformixFraction5},
+			arg:      tm,
+			expected: "'2024-02-12 15:04:05.12345'",
+		},
+		{
+			name:     "iso 8601",
+			ip:       &Interpolator{TimePreset: TimePresetISO8601},
+			arg:      tm,
+			expected: "'2024-02-12T15:04:05.123456Z'",
+		},
+		{
+			name:     "iso 8601 with offset",
+			ip:       &Interpolator{TimePreset: TimePresetISO8601},
+			arg:      east,
+			expected: "'2024-02-12T15:04:05+02:00'",
+		},
+		{
+			name:     "date only",
+			ip:       &Interpolator{TimePreset: TimePresetDateOnly},
+			arg:      tm,
+			expected: "'2024-02-12'",
+		},
+		{
+			name:     "preset overrides fraction settings",
+			ip:       &Interpolator{TimePreset: TimePresetInformixSecond, FractionDigits: 3, FixedFraction: true},
+			arg:      tm,
+			expected: "'2024-02-12 15:04:05'",
+		},
+		{
+			name:     "preset after location",
+			ip:       &Interpolator{TimePreset: TimePresetInformixSecond, Location: time.UTC},
+			arg:      east,
+			expected: "'2024-02-12 13:04:05'",
+		},
+		{
+			name:     "typed literals ignore preset",
+			ip:       &Interpolator{TimePreset: TimePresetDateOnly, TypedTimeLiterals: true},
+			arg:      tm,
+			expected: "TIMESTAMP '2024-02-12 15:04:05.123456'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.ip.formatArgument(tt.arg)
+			if err != nil {
+				t.Fatalf("formatArgument() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatTimeSlices(t *testing.T) {
+	tm := time.Date(2024, 2, 12, 15, 4, 5, 123456789, time.UTC)
+	east := time.Date(2024, 2, 12, 17, 4, 5, 0, time.FixedZone("", 2*60*60))
+
+	tests := []struct {
+		name     string
+		ip       *Interpolator
+		arg      interface{}
+		expected string
+	}{
+		{
+			name:     "informix collection with preset",
+			ip:       &Interpolator{Dialect: Informix, Collections: true, TimePreset: TimePresetInformixSecond},
+			arg:      []time.Time{tm, east},
+			expected: "LIST{'2024-02-12 15:04:05','2024-02-12 17:04:05'}",
+		},
+		{
+			name:     "informix collection with location",
+			ip:       &Interpolator{Dialect: Informix, Collections: true, TimePreset: TimePresetInformixSecond, Location: time.UTC},
+			arg:      []time.Time{east},
+			expected: "LIST{'2024-02-12 15:04:05'}",
+		},
+		{
+			name:     "informix collection of typed literals",
+			ip:       &Interpolator{Dialect: Informix, Collections: true, TypedTimeLiterals: true, FractionDigits: 3},
+			arg:      []time.Time{tm},
+			expected: "LIST{DATETIME(2024-02-12 15:04:05.123) YEAR TO FRACTION(3)}",
+		},
+		{
+			name:     "informix collection with zero as null",
+			ip:       &Interpolator{Dialect: Informix, Collections: true, ZeroTimeAsNull: true, TimePreset: TimePresetDateOnly},
+			arg:      []time.Time{tm, {}},
+			expected: "LIST{'2024-02-12',NULL}",
+		},
+		{
+			name:     "informix collection of pointers",
+			ip:       &Interpolator{Dialect: Informix, Collections: true, TimePreset: TimePresetInformixSecond},
+			arg:      []*time.Time{&tm, nil},
+			expected: "LIST{'2024-02-12 15:04:05',NULL}",
+		},
+		{
+			name:     "informix nested collection",
+			ip:       &Interpolator{Dialect: Informix, TimePreset: TimePresetInformixFraction5},
+			arg:      [][]time.Time{{tm}, {east}},
+			expected: "LIST{LIST{'2024-02-12 15:04:05.12345'},LIST{'2024-02-12 17:04:05.00000'}}",
+		},
+		{
+			name:     "informix empty collection",
+			ip:       &Interpolator{Dialect: Informix, Collections: true, EmptySlice: EmptyError},
+			arg:      []time.Time{},
+			expected: "LIST{}",
+		},
+		{
+			name:     "postgres array with preset",
+			ip:       &Interpolator{Collections: true, TimePreset: TimePresetISO8601},
+			arg:      []time.Time{east},
+			expected: "ARRAY['2024-02-12T17:04:05+02:00']",
+		},
+		{
+			name:     "list with preset",
+			ip:       &Interpolator{Dialect: Informix, TimePreset: TimePresetInformixSecond},
+			arg:      []time.Time{tm, east},
+			expected: "('2024-02-12 15:04:05','2024-02-12 17:04:05')",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.ip.formatArgument(tt.arg)
+			if err != nil {
+				t.Fatalf("formatArgument() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatZeroTime(t *testing.T) {
+	tm := time.Date(2024, 2, 12, 15, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		zeroNull bool
+		arg      time.Time
+		expected string
+	}{
+		{
+			name:     "zero literal",
+			arg:      time.Time{},
+			expected: "'0001-01-01 00:00:00'",
+		},
+		{
+			name:     "non-zero literal",
+			arg:      tm,
+			expected: "'2024-02-12 15:04:05'",
+		},
+		{
+			name:     "zero as null",
+			zeroNull: true,
+			arg:      time.Time{},
+			expected: "NULL",
+		},
+		{
+			name:     "non-zero with zero as null",
+			zeroNull: true,
+			arg:      tm,
+			expected: "'2024-02-12 15:04:05'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip := &Interpolator{ZeroTimeAsNull: tt.zeroNull}
+			got, err := ip.formatArgument(tt.arg)
+			if err != nil {
+				t.Fatalf("formatArgument() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatTypedNil(t *testing.T) {
+	var (
+		ptr   *int
+		slice []int
+		m     map[string]int
+		bytes []byte
+		pv    *ptrValuer
+		iface interface{} = ptr
+	)
+
+	tests := []struct {
+		name string
+		arg  interface{}
+	}{
+		{name: "pointer", arg: ptr},
+		{name: "slice", arg: slice},
+		{name: "map", arg: m},
+		{name: "bytes", arg: bytes},
+		{name: "valuer with pointer receiver", arg: pv},
+		{name: "pointer in interface", arg: iface},
+		{name: "named slice", arg: Text(nil)},
+		{name: "hstore", arg: HStore(nil)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.arg == nil {
+				t.Fatalf("test argument is an untyped nil")
+			}
+			got, err := formatArgument(tt.arg)
+			if err != nil {
+				t.Fatalf("formatArgument() error = %v", err)
+			}
+			if got != "NULL" {
+				t.Errorf("formatArgument() = %v, want NULL", got)
+			}
+		})
+	}
+}
+
+func TestFormatTimePointer(t *testing.T) {
+	tm := time.Date(2024, 2, 12, 15, 4, 5, 0, time.UTC)
+	var none *time.Time
+
+	tests := []struct {
+		name     string
+		ip       *Interpolator
+		arg      interface{}
+		expected string
+	}{
+		{
+			name:     "nil",
+			ip:       &Interpolator{},
+			arg:      none,
+			expected: "NULL",
+		},
+		{
+			name:     "non-nil",
+			ip:       &Interpolator{},
+			arg:      &tm,
+			expected: "'2024-02-12 15:04:05'",
+		},
+		{
+			name:     "typed informix literal",
+			ip:       &Interpolator{Dialect: Informix, TypedTimeLiterals: true},
+			arg:      &tm,
+			expected: "DATETIME(2024-02-12 15:04:05) YEAR TO SECOND",
+		},
+		{
+			name:     "pointer to zero time as null",
+			ip:       &Interpolator{ZeroTimeAsNull: true},
+			arg:      &time.Time{},
+			expected: "NULL",
+		},
+		{
+			name:     "slice with nil",
+			ip:       &Interpolator{},
+			arg:      []*time.Time{&tm, nil},
+			expected: "('2024-02-12 15:04:05',NULL)",
+		},
+		{
+			name:     "row field",
+			ip:       &Interpolator{},
+			arg:      Row{Value: struct{ At, Gone *time.Time }{At: &tm}},
+			expected: "ROW('2024-02-12 15:04:05',NULL)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.ip.formatArgument(tt.arg)
+			if err != nil {
+				t.Fatalf("formatArgument() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// packed implements encoding.BinaryMarshaler and fmt.Stringer.
+type packed struct {
+	a, b uint8
+	err  error
+}
+
+func (p packed) MarshalBinary() ([]byte, error) {
+	return []byte{p.a, p.b}, p.err
+}
+
+func (p packed) String() string {
+	return "packed"
+}
+
+func TestFormatUnsupportedTypes(t *testing.T) {
+	type phase complex128
+
+	tests := []struct {
+		name string
+		arg  interface{}
+	}{
+		{
+			name: "uintptr",
+			arg:  uintptr(0xc000010000),
+		},
+		{
+			name: "complex64",
+			arg:  complex64(1 + 2i),
+		},
+		{
+			name: "complex128",
+			arg:  1 + 2i,
+		},
+		{
+			name: "named complex",
+			arg:  phase(1i),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatArgument(tt.arg)
+			if !errors.Is(err, ErrUnsupportedType) {
+				t.Errorf("formatArgument() = %v, %v, want error %v", got, err, ErrUnsupportedType)
+			}
+			_, err = InterpolateQuery("SELECT $1", tt.arg)
+			if !errors.Is(err, ErrUnsupportedType) {
+				t.Errorf("InterpolateQuery() error = %v, want %v", err, ErrUnsupportedType)
+			}
+		})
+	}
+}
+
+func TestFormatNamedScalars(t *testing.T) {
+	type userID int64
+	type flags uint8
+	type ratio float64
+	type weight float32
+	type enabled bool
+	type status string
+	type Flags uint32
+	type Offset int64
+
+	tests := []struct {
+		name     string
+		arg      interface{}
+		expected string
+	}{
+		{
+			name:     "named int",
+			arg:      userID(-42),
+			expected: "-42",
+		},
+		{
+			name:     "named uint",
+			arg:      flags(255),
+			expected: "255",
+		},
+		{
+			name:     "named float",
+			arg:      ratio(0.125),
+			expected: "0.125000",
+		},
+		{
+			name:     "named float32",
+			arg:      weight(1.5),
+			expected: "1.500000",
+		},
+		{
+			name:     "named bool",
+			arg:      enabled(true),
+			expected: "true",
+		},
+		{
+			name:     "named string",
+			arg:      status("it's"),
+			expected: "'it''s'",
+		},
+		{
+			name:     "named uint32 above int32 range",
+			arg:      Flags(math.MaxUint32),
+			expected: "4294967295",
+		},
+		{
+			name:     "named negative int64",
+			arg:      Offset(math.MinInt64),
+			expected: "-9223372036854775808",
+		},
+		{
+			name:     "slice of named uint32",
+			arg:      []Flags{1 << 31, math.MaxUint32},
+			expected: "(2147483648,4294967295)",
+		},
+		{
+			name:     "slice of named negative int64",
+			arg:      []Offset{-1, math.MinInt64},
+			expected: "(-1,-9223372036854775808)",
+		},
+		{
+			name:     "slice of named ints",
+			arg:      []userID{1, 2},
+			expected: "(1,2)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatArgument(tt.arg)
+			if err != nil {
+				t.Fatalf("formatArgument() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatLargeUnsigned(t *testing.T) {
+	type counter uint64
+
+	tests := []struct {
+		name     string
+		arg      interface{}
+		expected string
+	}{
+		{
+			name:     "uint64",
+			arg:      uint64(math.MaxUint64),
+			expected: "18446744073709551615",
+		},
+		{
+			name:     "uint",
+			arg:      uint(math.MaxUint64),
+			expected: "18446744073709551615",
+		},
+		{
+			name:     "above int64",
+			arg:      uint64(math.MaxInt64) + 1,
+			expected: "9223372036854775808",
+		},
+		{
+			name:     "named uint64",
+			arg:      counter(math.MaxUint64),
+			expected: "18446744073709551615",
+		},
+		{
+			name:     "slice",
+			arg:      []uint64{0, math.MaxUint64},
+			expected: "(0,18446744073709551615)",
+		},
+		{
+			name:     "slice of named",
+			arg:      []counter{math.MaxUint64},
+			expected: "(18446744073709551615)",
+		},
+		{
+			name:     "array",
+			arg:      []interface{}{uint(math.MaxUint64)},
+			expected: "ARRAY[18446744073709551615]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatArgument(tt.arg)
+			if err != nil {
+				t.Fatalf("formatArgument() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// ptrValuer implements driver.Valuer on a pointer receiver.
+type ptrValuer struct {
+	value string
+}
+
+func (p *ptrValuer) Value() (driver.Value, error) {
+	return p.value, nil
+}
+
+func TestFormatSliceElements(t *testing.T) {
+	one, three := 1, 3
+	s := "it's"
+
+	tests := []struct {
+		name     string
+		arg      interface{}
+		expected string
+	}{
+		{
+			name:     "pointers with nil",
+			arg:      []*int{&one, nil, &three},
+			expected: "(1,NULL,3)",
+		},
+		{
+			name:     "valuers",
+			arg:      []customValuer{{value: "a"}, {value: "b"}},
+			expected: "('a','b')",
+		},
+		{
+			name:     "pointers to valuers",
+			arg:      []*customValuer{{value: "a"}, nil},
+			expected: "('a',NULL)",
+		},
+		{
+			name:     "pointer receiver valuers",
+			arg:      []*ptrValuer{{value: "a"}, nil},
+			expected: "('a',NULL)",
+		},
+		{
+			name:     "mixed interfaces",
+			arg:      []interface{}{customValuer{value: "a"}, nil, &s, (*int)(nil), 2},
+			expected: "ARRAY['a',NULL,'it''s',NULL,2]",
+		},
+		{
+			name:     "scalar pointer",
+			arg:      &one,
+			expected: "1",
+		},
+		{
+			name:     "pointer to pointer",
+			arg:      func() **string { p := &s; return &p }(),
+			expected: "'it''s'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatArgument(tt.arg)
+			if err != nil {
+				t.Fatalf("formatArgument() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatEmptySlice(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  Dialect
+		mode     EmptySliceMode
+		arg      interface{}
+		expected string
+		wantErr  error
+	}{
+		{
+			name:     "ints as parens",
+			arg:      []int{},
+			expected: "()",
+		},
+		{
+			name:     "strings as parens",
+			arg:      []string{},
+			expected: "()",
+		},
+		{
+			name:     "ints as null",
+			mode:     EmptyNull,
+			arg:      []int{},
+			expected: "(NULL)",
+		},
+		{
+			name:     "strings as null",
+			mode:     EmptyNull,
+			arg:      []string{},
+			expected: "(NULL)",
+		},
+		{
+			name:    "ints as error",
+			mode:    EmptyError,
+			arg:     []int{},
+			wantErr: ErrEmptySlice,
+		},
+		{
+			name:    "strings as error",
+			mode:    EmptyError,
+			arg:     []string{},
+			wantErr: ErrEmptySlice,
+		},
+		{
+			name:     "postgres collection",
+			mode:     EmptyCollection,
+			arg:      []int{},
+			expected: "ARRAY[]",
+		},
+		{
+			name:     "informix collection",
+			dialect:  Informix,
+			mode:     EmptyCollection,
+			arg:      []string{},
+			expected: "LIST{}",
+		},
+		{
+			name:     "non-empty unaffected",
+			mode:     EmptyError,
+			arg:      []string{"a"},
+			expected: "('a')",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip := &Interpolator{Dialect: tt.dialect, EmptySlice: tt.mode}
+			got, err := ip.InterpolateQuery("SELECT * FROM t WHERE a IN $1", tt.arg)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("InterpolateQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if want := "SELECT * FROM t WHERE a IN " + tt.expected; got != want {
+				t.Errorf("InterpolateQuery() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestFormatNilAndEmptySlices(t *testing.T) {
+	tests := []struct {
+		name     string
+		ip       *Interpolator
+		arg      interface{}
+		expected string
+	}{
+		{
+			name:     "nil",
+			ip:       &Interpolator{},
+			arg:      []int(nil),
+			expected: "NULL",
+		},
+		{
+			name:     "empty",
+			ip:       &Interpolator{},
+			arg:      []int{},
+			expected: "()",
+		},
+		{
+			name:     "nil with empty collection",
+			ip:       &Interpolator{EmptySlice: EmptyCollection},
+			arg:      []int(nil),
+			expected: "NULL",
+		},
+		{
+			name:     "empty with empty collection",
+			ip:       &Interpolator{EmptySlice: EmptyCollection},
+			arg:      []int{},
+			expected: "ARRAY[]",
+		},
+		{
+			name:     "nil with empty error",
+			ip:       &Interpolator{EmptySlice: EmptyError},
+			arg:      []string(nil),
+			expected: "NULL",
+		},
+		{
+			name:     "pointer to nil",
+			ip:       &Interpolator{},
+			arg:      new([]int),
+			expected: "NULL",
+		},
+		{
+			name:     "array",
+			ip:       &Interpolator{},
+			arg:      []interface{}{},
+			expected: "ARRAY[]",
+		},
+		{
+			name:     "nil and empty elements",
+			ip:       &Interpolator{},
+			arg:      []interface{}{[]int{}, []int(nil)},
+			expected: "ARRAY[[],NULL]",
+		},
+		{
+			name:     "informix nil and empty elements",
+			ip:       &Interpolator{Dialect: Informix},
+			arg:      [][]int{{}, nil},
+			expected: "LIST{LIST{},NULL}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.ip.formatArgument(tt.arg)
+			if err != nil {
+				t.Fatalf("formatArgument() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatNestedSlices(t *testing.T) {
+	one, three := 1, 3
+
+	tests := []struct {
+		name     string
+		dialect  Dialect
+		arg      interface{}
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "postgres ints",
+			dialect:  Postgres,
+			arg:      [][]int{{1, 2}, {3, 4}},
+			expected: "ARRAY[[1,2],[3,4]]",
+		},
+		{
+			name:     "postgres strings",
+			dialect:  Postgres,
+			arg:      [][]string{{"a", "b"}, {"it's", "d"}},
+			expected: "ARRAY[['a','b'],['it''s','d']]",
+		},
+		{
+			name:     "postgres three dimensions",
+			dialect:  Postgres,
+			arg:      [][][]int{{{1}, {2}}, {{3}, {4}}},
+			expected: "ARRAY[[[1],[2]],[[3],[4]]]",
+		},
+		{
+			name:     "postgres empty inner slices",
+			dialect:  Postgres,
+			arg:      [][]int{{}, {}},
+			expected: "ARRAY[[],[]]",
+		},
+		{
+			name:    "postgres jagged",
+			dialect: Postgres,
+			arg:     [][]int{{1, 2}, {3}},
+			wantErr: true,
+		},
+		{
+			name:     "postgres nested in interface slice",
+			dialect:  Postgres,
+			arg:      []interface{}{[]int{1, 2}, []int{3, 4}},
+			expected: "ARRAY[[1,2],[3,4]]",
+		},
+		{
+			name:     "nil elements",
+			dialect:  Postgres,
+			arg:      []interface{}{[]interface{}{1, nil}, []interface{}{nil, 2}},
+			expected: "ARRAY[[1,NULL],[NULL,2]]",
+		},
+		{
+			name:     "informix ints",
+			dialect:  Informix,
+			arg:      [][]int{{1, 2}, {3, 4}},
+			expected: "LIST{LIST{1,2},LIST{3,4}}",
+		},
+		{
+			name:     "informix strings",
+			dialect:  Informix,
+			arg:      [][]string{{"a"}, {"b", "c"}},
+			expected: "LIST{LIST{'a'},LIST{'b','c'}}",
+		},
+		{
+			name:     "informix jagged with nil and empty",
+			dialect:  Informix,
+			arg:      [][]int{{1, 2}, nil, {}},
+			expected: "LIST{LIST{1,2},NULL,LIST{}}",
+		},
+		{
+			name:     "informix nil pointers in list",
+			dialect:  Informix,
+			arg:      []*int{&one, nil, &three},
+			expected: "(1,NULL,3)",
+		},
+		{
+			name:     "informix nil pointers in collection",
+			dialect:  Informix,
+			arg:      []interface{}{&one, (*int)(nil), &three},
+			expected: "LIST{1,NULL,3}",
+		},
+		{
+			name:     "postgres nil pointers in nested array",
+			dialect:  Postgres,
+			arg:      [][]*int{{&one, nil}, {nil, &three}},
+			expected: "ARRAY[[1,NULL],[NULL,3]]",
+		},
+		{
+			name:     "byte slices stay binary",
+			dialect:  Postgres,
+			arg:      [][]byte{{0x01}, {0x02}},
+			expected: `('\x01','\x02')`,
+		},
+		{
+			name:     "array",
+			dialect:  Postgres,
+			arg:      [3]string{"a", "b", "c"},
+			expected: "('a','b','c')",
+		},
+		{
+			name:     "empty array",
+			dialect:  Postgres,
+			arg:      [0]int{},
+			expected: "()",
+		},
+		{
+			name:     "postgres array of arrays",
+			dialect:  Postgres,
+			arg:      [2][2]int{{1, 2}, {3, 4}},
+			expected: "ARRAY[[1,2],[3,4]]",
+		},
+		{
+			name:     "postgres slice of arrays",
+			dialect:  Postgres,
+			arg:      [][2]string{{"a", "b"}, {"c", "d"}},
+			expected: "ARRAY[['a','b'],['c','d']]",
+		},
+		{
+			name:     "informix array of slices",
+			dialect:  Informix,
+			arg:      [2][]int{{1}, {2, 3}},
+			expected: "LIST{LIST{1},LIST{2,3}}",
+		},
+		{
+			name:     "byte array is binary",
+			dialect:  Postgres,
+			arg:      [2]byte{0x01, 0x02},
+			expected: `'\x0102'`,
+		},
+		{
+			name:     "array of byte arrays",
+			dialect:  Postgres,
+			arg:      [2][1]byte{{0x01}, {0x02}},
+			expected: `('\x01','\x02')`,
+		},
+		{
+			name:     "uuid in array",
+			dialect:  Postgres,
+			arg:      [1][16]byte{{0x12, 0x34, 0x56, 0x78}},
+			expected: "('12345678-0000-0000-0000-000000000000')",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip := &Interpolator{Dialect: tt.dialect}
+			got, err := ip.formatArgument(tt.arg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("formatArgument() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatBoolDialect(t *testing.T) {
+	type enabled bool
+	yes := true
+	var none *bool
+
+	tests := []struct {
+		name      string
+		dialect   Dialect
+		boolAsInt bool
+		arg       interface{}
+		expected  string
+	}{
+		{
+			name:     "postgres scalar",
+			dialect:  Postgres,
+			arg:      true,
+			expected: "true",
+		},
+		{
+			name:     "informix scalar",
+			dialect:  Informix,
+			arg:      false,
+			expected: "'f'",
+		},
+		{
+			name:     "informix named",
+			dialect:  Informix,
+			arg:      enabled(true),
+			expected: "'t'",
+		},
+		{
+			name:     "informix slice",
+			dialect:  Informix,
+			arg:      []bool{true, false, true},
+			expected: "('t','f','t')",
+		},
+		{
+			name:     "informix array",
+			dialect:  Informix,
+			arg:      []interface{}{true, false, nil},
+			expected: "LIST{'t','f',NULL}",
+		},
+		{
+			name:     "informix nested",
+			dialect:  Informix,
+			arg:      [][]bool{{true}, {false}},
+			expected: "LIST{LIST{'t'},LIST{'f'}}",
+		},
+		{
+			name:     "informix nil pointer",
+			dialect:  Informix,
+			arg:      none,
+			expected: "NULL",
+		},
+		{
+			name:     "informix pointer",
+			dialect:  Informix,
+			arg:      &yes,
+			expected: "'t'",
+		},
+		{
+			name:     "informix invalid null bool",
+			dialect:  Informix,
+			arg:      sql.NullBool{Valid: false},
+			expected: "NULL",
+		},
+		{
+			name:     "informix valid null bool",
+			dialect:  Informix,
+			arg:      sql.NullBool{Bool: false, Valid: true},
+			expected: "'f'",
+		},
+		{
+			name:     "informix generic null bool",
+			dialect:  Informix,
+			arg:      sql.Null[bool]{V: true, Valid: true},
+			expected: "'t'",
+		},
+		{
+			name:     "informix pointers in list",
+			dialect:  Informix,
+			arg:      []*bool{&yes, nil},
+			expected: "('t',NULL)",
+		},
+		{
+			name:      "bool as int null bool",
+			boolAsInt: true,
+			arg:       sql.NullBool{Bool: true, Valid: true},
+			expected:  "1",
+		},
+		{
+			name:     "postgres array",
+			dialect:  Postgres,
+			arg:      []interface{}{true, false},
+			expected: "ARRAY[true,false]",
+		},
+		{
+			name:      "bool as int true",
+			boolAsInt: true,
+			arg:       true,
+			expected:  "1",
+		},
+		{
+			name:      "bool as int false",
+			boolAsInt: true,
+			arg:       false,
+			expected:  "0",
+		},
+		{
+			name:      "bool as int informix",
+			dialect:   Informix,
+			boolAsInt: true,
+			arg:       []bool{true, false},
+			expected:  "(1,0)",
+		},
+		{
+			name:      "bool as int mysql named",
+			dialect:   MySQL,
+			boolAsInt: true,
+			arg:       enabled(true),
+			expected:  "1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip := &Interpolator{Dialect: tt.dialect, BoolAsInt: tt.boolAsInt}
+			got, err := ip.formatArgument(tt.arg)
+			if err != nil {
+				t.Fatalf("formatArgument() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatTextTypes(t *testing.T) {
+	id := [16]byte{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}
+
+	tests := []struct {
+		name     string
+		arg      interface{}
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "uuid",
+			arg:      uuid(id),
+			expected: "'123e4567-e89b-12d3-a456-426614174000'",
+		},
+		{
+			name:     "raw uuid bytes",
+			arg:      id,
+			expected: "'123e4567-e89b-12d3-a456-426614174000'",
+		},
+		{
+			name:     "stringer",
+			arg:      color(1),
+			expected: "'green'",
+		},
+		{
+			name:    "text marshaler error",
+			arg:     badText{},
+			wantErr: true,
+		},
+		{
+			name:     "binary marshaler",
+			arg:      packed{a: 0xca, b: 0xfe},
+			expected: "'\\xcafe'",
+		},
+		{
+			name:    "binary marshaler error",
+			arg:     packed{err: errors.New("cannot marshal")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatArgument(tt.arg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("formatArgument() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatNetworkAddresses(t *testing.T) {
+	_, cidr, err := net.ParseCIDR("192.168.0.0/24")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		arg      interface{}
+		expected string
+	}{
+		{
+			name:     "ipv4",
+			arg:      net.ParseIP("127.0.0.1"),
+			expected: "'127.0.0.1'",
+		},
+		{
+			name:     "ipv4 bytes",
+			arg:      net.IPv4(10, 0, 0, 1).To4(),
+			expected: "'10.0.0.1'",
+		},
+		{
+			name:     "ipv6",
+			arg:      net.ParseIP("2001:db8::1"),
+			expected: "'2001:db8::1'",
+		},
+		{
+			name:     "nil ip",
+			arg:      net.IP(nil),
+			expected: "NULL",
+		},
+		{
+			name:     "cidr",
+			arg:      cidr,
+			expected: "'192.168.0.0/24'",
+		},
+		{
+			name:     "cidr value",
+			arg:      *cidr,
+			expected: "'192.168.0.0/24'",
+		},
+		{
+			name:     "nil cidr",
+			arg:      (*net.IPNet)(nil),
+			expected: "NULL",
+		},
+		{
+			name:     "hardware address",
+			arg:      net.HardwareAddr{0x00, 0x11, 0x22, 0x33, 0x44, 0x55},
+			expected: "'00:11:22:33:44:55'",
+		},
+		{
+			name:     "nil hardware address",
+			arg:      net.HardwareAddr(nil),
+			expected: "NULL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatArgument(tt.arg)
+			if err != nil {
+				t.Fatalf("formatArgument() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatNestedValuer(t *testing.T) {
+	tm := time.Date(2024, 2, 12, 15, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		arg      interface{}
+		expected string
+		wantErr  bool
+	}{
+		{
+			name: "valuer returning time",
+			arg: valuerFunc(func() (driver.Value, error) {
+				return tm, nil
+			}),
+			expected: "'2024-02-12 15:04:05'",
+		},
+		{
+			name: "valuer returning valuer",
+			arg: valuerFunc(func() (driver.Value, error) {
+				return customValuer{value: "inner"}, nil
+			}),
+			expected: "'inner'",
+		},
+		{
+			name: "valuer returning bytes",
+			arg: valuerFunc(func() (driver.Value, error) {
+				return []byte{0xab}, nil
+			}),
+			expected: "'\\xab'",
+		},
+		{
+			name: "valuer returning nil",
+			arg: valuerFunc(func() (driver.Value, error) {
+				return nil, nil
+			}),
+			expected: "NULL",
+		},
+		{
+			name: "valuer returning nil int pointer",
+			arg: valuerFunc(func() (driver.Value, error) {
+				return (*int)(nil), nil
+			}),
+			expected: "NULL",
+		},
+		{
+			name: "valuer returning nil time pointer",
+			arg: valuerFunc(func() (driver.Value, error) {
+				return (*time.Time)(nil), nil
+			}),
+			expected: "NULL",
+		},
+		{
+			name: "valuer returning nil rat",
+			arg: valuerFunc(func() (driver.Value, error) {
+				return (*big.Rat)(nil), nil
+			}),
+			expected: "NULL",
+		},
+		{
+			name: "valuer returning nil slice",
+			arg: valuerFunc(func() (driver.Value, error) {
+				return []int(nil), nil
+			}),
+			expected: "NULL",
+		},
+		{
+			name: "valuer error",
+			arg: valuerFunc(func() (driver.Value, error) {
+				return nil, errors.New("boom")
+			}),
+			wantErr: true,
+		},
+		{
+			name:    "cyclic valuer",
+			arg:     cyclicValuer{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatArgument(tt.arg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("formatArgument() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatSQLNull(t *testing.T) {
+	tm := time.Date(2024, 2, 12, 15, 4, 5, 123000, time.UTC)
+
+	tests := []struct {
+		name     string
+		arg      interface{}
+		expected string
+	}{
+		{
+			name:     "valid int",
+			arg:      sql.Null[int]{V: 42, Valid: true},
+			expected: "42",
+		},
+		{
+			name:     "invalid int",
+			arg:      sql.Null[int]{V: 42},
+			expected: "NULL",
+		},
+		{
+			name:     "valid time",
+			arg:      sql.Null[time.Time]{V: tm, Valid: true},
+			expected: "'2024-02-12 15:04:05.000123'",
+		},
+		{
+			name:     "invalid time",
+			arg:      sql.Null[time.Time]{},
+			expected: "NULL",
+		},
+		{
+			name:     "valid interval",
+			arg:      sql.Null[Interval]{V: Interval{Duration: time.Hour}, Valid: true},
+			expected: "INTERVAL(0 01:00:00) DAY TO SECOND",
+		},
+		{
+			name:     "valid valuer",
+			arg:      sql.Null[customValuer]{V: customValuer{value: "custom"}, Valid: true},
+			expected: "'custom'",
+		},
+		{
+			name:     "NullString",
+			arg:      sql.NullString{String: "x", Valid: true},
+			expected: "'x'",
+		},
+		{
+			name:     "invalid NullInt64",
+			arg:      sql.NullInt64{Int64: 1},
+			expected: "NULL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatArgument(tt.arg)
+			if err != nil {
+				t.Fatalf("formatArgument() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatRunesAndText(t *testing.T) {
+	tests := []struct {
+		name     string
+		arg      interface{}
+		expected string
+	}{
+		{
+			name:     "rune is numeric",
+			arg:      'A',
+			expected: "65",
+		},
+		{
+			name:     "int32",
+			arg:      int32(65),
+			expected: "65",
+		},
+		{
+			name:     "char",
+			arg:      Char('A'),
+			expected: "'A'",
+		},
+		{
+			name:     "quote char",
+			arg:      Char('\''),
+			expected: "''''",
+		},
+		{
+			name:     "multi-byte char",
+			arg:      Char('é'),
+			expected: "'é'",
+		},
+		{
+			name:     "runes",
+			arg:      Runes("it's"),
+			expected: "'it''s'",
+		},
+		{
+			name:     "rune slice",
+			arg:      []rune("AB"),
+			expected: "(65,66)",
+		},
+		{
+			name:     "int32 slice",
+			arg:      []int32{1, 2, 39},
+			expected: "(1,2,39)",
+		},
+		{
+			name:     "int32 collection",
+			arg:      [][]int32{{1, 2}, {39, 40}},
+			expected: "ARRAY[[1,2],[39,40]]",
+		},
+		{
+			name:     "text bytes",
+			arg:      Text("it's text"),
+			expected: "'it''s text'",
+		},
+		{
+			name:     "binary bytes",
+			arg:      []byte("AB"),
+			expected: "'\\x4142'",
+		},
+		{
+			name:     "raw bytes",
+			arg:      sql.RawBytes("it's text"),
+			expected: "'it''s text'",
+		},
+		{
+			name:     "nil raw bytes",
+			arg:      sql.RawBytes(nil),
+			expected: "NULL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatArgument(tt.arg)
+			if err != nil {
+				t.Fatalf("formatArgument() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatJSON(t *testing.T) {
+	type doc struct {
+		Name string   `json:"name"`
+		Tags []string `json:"tags"`
+	}
+
+	tests := []struct {
+		name     string
+		arg      interface{}
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "map",
+			arg:      map[string]interface{}{"b": 2, "a": "it's"},
+			expected: `'{"a":"it''s","b":2}'`,
+		},
+		{
+			name:     "struct",
+			arg:      doc{Name: "x", Tags: []string{"y"}},
+			expected: `'{"name":"x","tags":["y"]}'`,
+		},
+		{
+			name:     "map of ints",
+			arg:      map[string]int{"b": 2, "a": 1, "c": 3},
+			expected: `'{"a":1,"b":2,"c":3}'`,
+		},
+		{
+			name:     "map with int keys",
+			arg:      map[int]string{10: "ten", 2: "it's", 1: "one"},
+			expected: `'{"1":"one","10":"ten","2":"it''s"}'`,
+		},
+		{
+			name:     "nil map",
+			arg:      map[string]int(nil),
+			expected: "NULL",
+		},
+		{
+			name:    "map with unsupported keys",
+			arg:     map[[2]int]string{{1, 2}: "x"},
+			wantErr: true,
+		},
+		{
+			name:     "raw message",
+			arg:      json.RawMessage(`{"k": [1, 2]}`),
+			expected: `'{"k": [1, 2]}'`,
+		},
+		{
+			name:     "nil raw message",
+			arg:      json.RawMessage(nil),
+			expected: "NULL",
+		},
+		{
+			name:    "invalid raw message",
+			arg:     json.RawMessage(`{"k":`),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatArgument(tt.arg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("formatArgument() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatNumber(t *testing.T) {
+	tests := []struct {
+		name     string
+		arg      json.Number
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "integer",
+			arg:      json.Number("12345678901234567890123"),
+			expected: "12345678901234567890123",
+		},
+		{
+			name:     "decimal",
+			arg:      json.Number("-0.1234567890123456789"),
+			expected: "-0.1234567890123456789",
+		},
+		{
+			name:     "exponent",
+			arg:      json.Number("1e-7"),
+			expected: "1e-7",
+		},
+		{
+			name:    "invalid",
+			arg:     json.Number("1; DROP TABLE users"),
+			wantErr: true,
+		},
+		{
+			name:    "not a number",
+			arg:     json.Number("true"),
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			arg:     json.Number(""),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatArgument(tt.arg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("formatArgument() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEscapeString(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "normal string",
+			input:    "hello",
+			expected: "'hello'",
+		},
+		{
+			name:     "string with single quote",
+			input:    "O'Connor",
+			expected: "'O''Connor'",
+		},
+		{
+			name:     "string with multiple quotes",
+			input:    "It's O'Connor's",
+			expected: "'It''s O''Connor''s'",
+		},
+		{
+			name:     "empty string",
+			input:    "",
+			expected: "''",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := escapeString(tt.input)
+			if got != tt.expected {
+				t.Errorf("escapeString() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExtendedStrings(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  Dialect
+		extended bool
+		arg      interface{}
+		expected string
+	}{
+		{
+			name:     "standard",
+			arg:      `C:\temp it's`,
+			expected: `'C:\temp it''s'`,
+		},
+		{
+			name:     "extended",
+			extended: true,
+			arg:      `C:\temp it's`,
+			expected: `E'C:\\temp it''s'`,
+		},
+		{
+			name:     "extended without backslash",
+			extended: true,
+			arg:      "plain",
+			expected: "E'plain'",
+		},
+		{
+			name:     "extended bytes",
+			extended: true,
+			arg:      []byte{0xca, 0xfe},
+			expected: `E'\\xcafe'`,
+		},
+		{
+			name:     "extended like value",
+			extended: true,
+			arg:      LikeValue("50%"),
+			expected: `E'50\\%'`,
+		},
+		{
+			name:     "informix ignores extended",
+			dialect:  Informix,
+			extended: true,
+			arg:      `C:\temp`,
+			expected: `'C:\temp'`,
+		},
+		{
+			name:     "mysql ignores extended",
+			dialect:  MySQL,
+			extended: true,
+			arg:      `C:\temp`,
+			expected: `'C:\\temp'`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip := &Interpolator{Dialect: tt.dialect, ExtendedStrings: tt.extended}
+			got, err := ip.formatArgument(tt.arg)
+			if err != nil {
+				t.Fatalf("formatArgument() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatBytesEncoding(t *testing.T) {
+	input := []byte{0xde, 0xad, 0xbe, 0xef, 0x00}
+
+	tests := []struct {
+		name     string
+		ip       *Interpolator
+		expected string
+	}{
+		{
+			name:     "hex",
+			ip:       &Interpolator{},
+			expected: "'\\xdeadbeef00'",
+		},
+		{
+			name:     "informix hex",
+			ip:       &Interpolator{Dialect: Informix, BinaryEncoding: HexEncoding},
+			expected: "'deadbeef00'",
+		},
+		{
+			name:     "base64",
+			ip:       &Interpolator{BinaryEncoding: Base64Encoding},
+			expected: "'3q2+7wA='",
+		},
+		{
+			name:     "informix base64",
+			ip:       &Interpolator{Dialect: Informix, BinaryEncoding: Base64Encoding},
+			expected: "'3q2+7wA='",
+		},
+		{
+			name:     "backslash hex",
+			ip:       &Interpolator{BinaryEncoding: BackslashHexEncoding},
+			expected: "'\\xdeadbeef00'",
+		},
+		{
+			name:     "informix backslash hex",
+			ip:       &Interpolator{Dialect: Informix, BinaryEncoding: BackslashHexEncoding},
+			expected: "'\\xdeadbeef00'",
+		},
+		{
+			name:     "extended backslash hex",
+			ip:       &Interpolator{BinaryEncoding: BackslashHexEncoding, ExtendedStrings: true},
+			expected: `E'\\xdeadbeef00'`,
+		},
+		{
+			name:     "mysql backslash hex",
+			ip:       &Interpolator{Dialect: MySQL, BinaryEncoding: BackslashHexEncoding},
+			expected: `'\\xdeadbeef00'`,
+		},
+		{
+			name:     "backslash quote backslash hex",
+			ip:       &Interpolator{BinaryEncoding: BackslashHexEncoding, QuoteEscape: BackslashQuote},
+			expected: `'\\xdeadbeef00'`,
+		},
+		{
+			name:     "backslash quote hex",
+			ip:       &Interpolator{QuoteEscape: BackslashQuote},
+			expected: `'\\xdeadbeef00'`,
+		},
+		{
+			name:     "prefix hex",
+			ip:       &Interpolator{BinaryEncoding: PrefixHexEncoding},
+			expected: "0xdeadbeef00",
+		},
+		{
+			name:     "mysql prefix hex",
+			ip:       &Interpolator{Dialect: MySQL, BinaryEncoding: PrefixHexEncoding},
+			expected: "0xdeadbeef00",
+		},
+		{
+			name:     "ansi hex",
+			ip:       &Interpolator{BinaryEncoding: ANSIHexEncoding},
+			expected: "X'deadbeef00'",
+		},
+		{
+			name:     "informix ansi hex",
+			ip:       &Interpolator{Dialect: Informix, BinaryEncoding: ANSIHexEncoding},
+			expected: "X'deadbeef00'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.ip.formatArgument(input)
+			if err != nil {
+				t.Fatalf("formatArgument() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatBytesValuer(t *testing.T) {
+	bytes := valuerFunc(func() (driver.Value, error) {
+		return []byte{0xDE, 0xAD}, nil
+	})
+	nested := valuerFunc(func() (driver.Value, error) {
+		return bytes, nil
+	})
+
+	tests := []struct {
+		name     string
+		ip       *Interpolator
+		expected string
+	}{
+		{
+			name:     "postgres",
+			ip:       &Interpolator{},
+			expected: `'\xdead'`,
+		},
+		{
+			name:     "informix",
+			ip:       &Interpolator{Dialect: Informix},
+			expected: "'dead'",
+		},
+		{
+			name:     "mysql",
+			ip:       &Interpolator{Dialect: MySQL},
+			expected: "X'dead'",
+		},
+		{
+			name:     "base64",
+			ip:       &Interpolator{BinaryEncoding: Base64Encoding},
+			expected: "'3q0='",
+		},
+		{
+			name:     "informix base64",
+			ip:       &Interpolator{Dialect: Informix, BinaryEncoding: Base64Encoding},
+			expected: "'3q0='",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, arg := range []interface{}{bytes, &bytes, nested} {
+				got, err := tt.ip.formatArgument(arg)
+				if err != nil {
+					t.Fatalf("formatArgument(%T) error = %v", arg, err)
+				}
+				if got != tt.expected {
+					t.Errorf("formatArgument(%T) = %v, want %v", arg, got, tt.expected)
+				}
+			}
+		})
+	}
+}
+
+// hexBytes is a []byte type with a text form.
+type hexBytes []byte
+
+func (h hexBytes) String() string { return fmt.Sprintf("%x", []byte(h)) }
+
+func TestFormatNamedBytes(t *testing.T) {
+	type blob []byte
+	hw := net.HardwareAddr{0x00, 0x11, 0x22, 0x33, 0x44, 0x55}
+
+	tests := []struct {
+		name     string
+		arg      interface{}
+		expected string
+	}{
+		{
+			name:     "plain bytes",
+			arg:      []byte{0xDE, 0xAD},
+			expected: `'\xdead'`,
+		},
+		{
+			name:     "named bytes",
+			arg:      blob{0xDE, 0xAD},
+			expected: `'\xdead'`,
+		},
+		{
+			name:     "empty named bytes",
+			arg:      blob{},
+			expected: `'\x'`,
+		},
+		{
+			name:     "stringer",
+			arg:      hexBytes{0xDE, 0xAD},
+			expected: "'dead'",
+		},
+		{
+			name:     "hardware address",
+			arg:      hw,
+			expected: "'00:11:22:33:44:55'",
+		},
+		{
+			name:     "hardware address pointer",
+			arg:      &hw,
+			expected: "'00:11:22:33:44:55'",
+		},
+		{
+			name:     "hardware address list",
+			arg:      []net.HardwareAddr{hw},
+			expected: "('00:11:22:33:44:55')",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatArgument(tt.arg)
+			if err != nil {
+				t.Fatalf("formatArgument() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestQuoteBytes(t *testing.T) {
+	small := []byte{0x1, 0x2, 0x3}
+	large := make([]byte, 1025)
+
+	tests := []struct {
+		name     string
+		ip       *Interpolator
+		input    []byte
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "small",
+			ip:       &Interpolator{MaxInlineBytes: 1024},
+			input:    small,
+			expected: "'\\x010203'",
+		},
+		{
+			name:     "small informix",
+			ip:       &Interpolator{Dialect: Informix, MaxInlineBytes: 1024},
+			input:    small,
+			expected: "'010203'",
+		},
+		{
+			name:     "small base64",
+			ip:       &Interpolator{BinaryEncoding: Base64Encoding, MaxInlineBytes: 1024},
+			input:    small,
+			expected: "'AQID'",
+		},
+		{
+			name:    "over threshold",
+			ip:      &Interpolator{MaxInlineBytes: 1024},
+			input:   large,
+			wantErr: true,
+		},
+		{
+			name:     "no threshold",
+			ip:       &Interpolator{},
+			input:    large[:2],
+			expected: "'\\x0000'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.ip.QuoteBytes(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("QuoteBytes() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.expected {
+				t.Errorf("QuoteBytes() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+
+	ip := &Interpolator{MaxInlineBytes: 1024}
+	if _, err := ip.InterpolateQuery("INSERT INTO t VALUES ($1)", large); err == nil {
+		t.Errorf("InterpolateQuery() with over threshold bytes should fail")
+	}
+}
+
+func TestEscapeStringDialect(t *testing.T) {
+	input := "C:\\temp\nO'Connor"
+
+	tests := []struct {
+		name     string
+		dialect  Dialect
+		input    string
+		expected string
+	}{
+		{
+			name:     "postgres",
+			dialect:  Postgres,
+			input:    input,
+			expected: "'C:\\temp\nO''Connor'",
+		},
+		{
+			name:     "informix",
+			dialect:  Informix,
+			input:    input,
+			expected: "'C:\\temp\nO''Connor'",
+		},
+		{
+			name:     "mysql",
+			dialect:  MySQL,
+			input:    input,
+			expected: `'C:\\temp\nO''Connor'`,
+		},
+		{
+			name:     "mysql control characters",
+			dialect:  MySQL,
+			input:    "a\x00b\rc\x1ad",
+			expected: `'a\0b\rc\Zd'`,
+		},
+		{
+			name:     "mysql invalid utf-8",
+			dialect:  MySQL,
+			input:    "\xbf\\'",
+			expected: `X'bf5c27'`,
+		},
+		{
+			name:     "postgres invalid utf-8",
+			dialect:  Postgres,
+			input:    "\xbf\\'",
+			expected: "'\xbf\\'''",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip := &Interpolator{Dialect: tt.dialect}
+			got := ip.escapeString(tt.input)
+			if got != tt.expected {
+				t.Errorf("escapeString() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEscapeStringQuoteEscape(t *testing.T) {
+	tests := []struct {
+		name     string
+		ip       *Interpolator
+		input    string
+		expected string
+	}{
+		{
+			name:     "double quote",
+			ip:       &Interpolator{},
+			input:    "O'Connor",
+			expected: `'O''Connor'`,
+		},
+		{
+			name:     "backslash quote",
+			ip:       &Interpolator{QuoteEscape: BackslashQuote},
+			input:    "O'Connor",
+			expected: `'O\'Connor'`,
+		},
+		{
+			name:     "informix backslash quote",
+			ip:       &Interpolator{Dialect: Informix, QuoteEscape: BackslashQuote},
+			input:    "O'Connor",
+			expected: `'O\'Connor'`,
+		},
+		{
+			name:     "mysql backslash quote",
+			ip:       &Interpolator{Dialect: MySQL, QuoteEscape: BackslashQuote},
+			input:    "O'Connor",
+			expected: `'O\'Connor'`,
+		},
+		{
+			name:     "backslash before quote",
+			ip:       &Interpolator{QuoteEscape: BackslashQuote},
+			input:    `x\' OR 1=1 --`,
+			expected: `'x\\\' OR 1=1 --'`,
+		},
+		{
+			name:     "mysql backslash before quote",
+			ip:       &Interpolator{Dialect: MySQL, QuoteEscape: BackslashQuote},
+			input:    `x\'`,
+			expected: `'x\\\''`,
+		},
+		{
+			name:     "extended backslash quote",
+			ip:       &Interpolator{ExtendedStrings: true, QuoteEscape: BackslashQuote},
+			input:    `O'C\`,
+			expected: `E'O\'C\\'`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.ip.escapeString(tt.input)
+			if got != tt.expected {
+				t.Errorf("escapeString() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatControlChars(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     ControlCharMode
+		dialect  Dialect
+		arg      interface{}
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "kept by default",
+			arg:      "\ufeffname\x01",
+			expected: "'\ufeffname\x01'",
+		},
+		{
+			name:     "strip byte order mark",
+			mode:     StripControlChars,
+			arg:      "\ufeffname",
+			expected: "'name'",
+		},
+		{
+			name:     "strip embedded control character",
+			mode:     StripControlChars,
+			arg:      "na\x00me\x1b\t\r\n",
+			expected: "'name\t\r\n'",
+		},
+		{
+			name:     "strip keeps inner byte order mark",
+			mode:     StripControlChars,
+			arg:      "a\ufeffb",
+			expected: "'a\ufeffb'",
+		},
+		{
+			name:     "strip text",
+			mode:     StripControlChars,
+			arg:      Text("\ufeffa\x07b"),
+			expected: "'ab'",
+		},
+		{
+			name:     "strip keeps invalid utf-8",
+			mode:     StripControlChars,
+			dialect:  MySQL,
+			arg:      "\xbf\x01",
+			expected: "X'bf'",
+		},
+		{
+			name:    "reject byte order mark",
+			mode:    RejectControlChars,
+			arg:     "\ufeffname",
+			wantErr: true,
+		},
+		{
+			name:    "reject embedded control character",
+			mode:    RejectControlChars,
+			arg:     "na\x00me",
+			wantErr: true,
+		},
+		{
+			name:    "reject in list",
+			mode:    RejectControlChars,
+			arg:     []string{"ok", "b\x08ad"},
+			wantErr: true,
+		},
+		{
+			name:     "reject allows whitespace",
+			mode:     RejectControlChars,
+			arg:      "a\tb\r\nc",
+			expected: "'a\tb\r\nc'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip := &Interpolator{Dialect: tt.dialect, ControlChars: tt.mode}
+			got, err := ip.formatArgument(tt.arg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("formatArgument() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatMapsDeterministic(t *testing.T) {
+	m := map[string]interface{}{}
+	h := HStore{}
+	for _, k := range []string{"delta", "alpha", "echo", "charlie", "bravo", "foxtrot", "golf", "hotel"} {
+		m[k] = len(k)
+		h[k] = k
+	}
+	type doc struct {
+		Tags  map[string]int
+		Attrs map[string]interface{}
+	}
+
+	tests := []struct {
+		name string
+		arg  interface{}
+	}{
+		{name: "JSON object", arg: m},
+		{name: "map of ints", arg: map[string]int{"b": 2, "a": 1, "c": 3, "d": 4, "e": 5}},
+		{name: "map with int keys", arg: map[int]string{3: "c", 1: "a", 2: "b", 10: "j"}},
+		{name: "struct with maps", arg: doc{Tags: map[string]int{"y": 1, "x": 2, "z": 3}, Attrs: m}},
+		{name: "hstore", arg: h},
+		{name: "row from map", arg: Row{Value: m}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, err := formatArgument(tt.arg)
+			if err != nil {
+				t.Fatalf("formatArgument() error = %v", err)
+			}
+			for i := 0; i < 100; i++ {
+				got, err := formatArgument(tt.arg)
+				if err != nil {
+					t.Fatalf("formatArgument() error = %v", err)
+				}
+				if got != first {
+					t.Fatalf("formatArgument() = %v on iteration %d, want %v", got, i, first)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatEmptyStringAsNull(t *testing.T) {
+	type status string
+
+	tests := []struct {
+		name     string
+		asNull   bool
+		arg      interface{}
+		expected string
+	}{
+		{
+			name:     "empty string",
+			arg:      "",
+			expected: "''",
+		},
+		{
+			name:     "empty string as null",
+			asNull:   true,
+			arg:      "",
+			expected: "NULL",
+		},
+		{
+			name:     "non-empty string as null",
+			asNull:   true,
+			arg:      "a",
+			expected: "'a'",
+		},
+		{
+			name:     "named empty string as null",
+			asNull:   true,
+			arg:      status(""),
+			expected: "NULL",
+		},
+		{
+			name:     "valid empty null string",
+			arg:      sql.NullString{Valid: true},
+			expected: "''",
+		},
+		{
+			name:     "valid empty null string as null",
+			asNull:   true,
+			arg:      sql.NullString{Valid: true},
+			expected: "NULL",
+		},
+		{
+			name:     "invalid null string",
+			arg:      sql.NullString{String: "a"},
+			expected: "NULL",
+		},
+		{
+			name:     "generic valid empty null string as null",
+			asNull:   true,
+			arg:      sql.Null[string]{Valid: true},
+			expected: "NULL",
+		},
+		{
+			name:     "list as null",
+			asNull:   true,
+			arg:      []string{"", "a"},
+			expected: "(NULL,'a')",
+		},
+		{
+			name:     "empty text kept",
+			asNull:   true,
+			arg:      Text(""),
+			expected: "''",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip := &Interpolator{EmptyStringAsNull: tt.asNull}
+			got, err := ip.formatArgument(tt.arg)
+			if err != nil {
+				t.Fatalf("formatArgument() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatArray(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []interface{}
+		expected string
+	}{
+		{
+			name:     "string array",
+			input:    []interface{}{"a", "b", "c"},
+			expected: "ARRAY['a','b','c']",
+		},
+		{
+			name:     "mixed array",
+			input:    []interface{}{1, "two", true},
+			expected: "ARRAY[1,'two',true]",
+		},
+		{
+			name:     "empty array",
+			input:    []interface{}{},
+			expected: "ARRAY[]",
+		},
+		{
+			name:     "array with null",
+			input:    []interface{}{nil, "value", nil},
+			expected: "ARRAY[NULL,'value',NULL]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatArray(tt.input)
+			if err != nil {
+				t.Fatalf("formatArray() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("formatArray() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatBytesDialect(t *testing.T) {
+	input := []byte{0x1, 0x2, 0x3}
+
+	tests := []struct {
+		name     string
+		dialect  Dialect
+		expected string
+	}{
+		{
+			name:     "postgres",
+			dialect:  Postgres,
+			expected: "'\\x010203'",
+		},
+		{
+			name:     "informix",
+			dialect:  Informix,
+			expected: "'010203'",
+		},
+		{
+			name:     "mysql",
+			dialect:  MySQL,
+			expected: "X'010203'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip := &Interpolator{Dialect: tt.dialect}
+			got, err := ip.formatArgument(input)
+			if err != nil {
+				t.Fatalf("formatArgument() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+
+	pg, _ := (&Interpolator{Dialect: Postgres}).formatArgument(input)
+	ifx, _ := (&Interpolator{Dialect: Informix}).formatArgument(input)
+	if pg == ifx {
+		t.Errorf("Informix and Postgres byte literals should differ, both are %v", pg)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	check := func(name, got string, err error, expected string) {
+		t.Helper()
+		if err != nil {
+			t.Errorf("%s: Format() error = %v", name, err)
+			return
+		}
+		if got != expected {
+			t.Errorf("%s: Format() = %v, want %v", name, got, expected)
+		}
+	}
+
+	got, err := Format(42)
+	check("int", got, err, "42")
+
+	got, err = Format("O'Connor")
+	check("string", got, err, "'O''Connor'")
+
+	got, err = Format(time.Date(2024, 2, 12, 15, 4, 5, 0, time.UTC))
+	check("time", got, err, "'2024-02-12 15:04:05'")
+
+	got, err = Format(customValuer{value: "custom"})
+	check("valuer", got, err, "'custom'")
+
+	_, err = Format(json.Number("x"))
+	if err == nil {
+		t.Errorf("Format() of invalid number should fail")
+	}
+
+	// Format must agree with InterpolateQuery.
+	v := customValuer{value: "it's"}
+	got, _ = Format(v)
+	interpolated, _ := InterpolateQuery("$1", v)
+	if got != interpolated {
+		t.Errorf("Format() = %v, InterpolateQuery() = %v", got, interpolated)
+	}
+}
+
+// Benchmark the main function
+func BenchmarkInterpolateQuery(b *testing.B) {
+	query := "SELECT * FROM users WHERE id = $1 AND name = $2 AND active = $3"
+	args := []interface{}{123, "John", true}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := InterpolateQuery(query, args...)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkInterpolateQueryList(b *testing.B) {
+	query := "SELECT * FROM users WHERE id IN $1 AND name IN $2"
+	args := []interface{}{[]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, []string{"a", "b", "c", "d", "e"}}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, err := InterpolateQuery(query, args...)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkFormatArrayLarge(b *testing.B) {
+	ints := make([]int, 100000)
+	elems := make([]interface{}, len(ints))
+	for i := range ints {
+		ints[i] = i
+		elems[i] = i
+	}
+
+	b.Run("list", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := formatArgument(ints); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("array", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := formatArray(elems); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
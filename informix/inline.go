@@ -0,0 +1,11 @@
+package informix
+
+// Inline wraps an argument that Parameterize writes into the query as a
+// literal instead of binding it to a ? marker. It lets a query inline
+// constants, such as a status code or a table-wide limit, while values
+// that come from users stay bound. Raw arguments are always inlined.
+//
+// Everywhere else Inline is formatted like the value it wraps.
+type Inline struct {
+	Value interface{}
+}
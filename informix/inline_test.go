@@ -0,0 +1,13 @@
+package informix
+
+import "testing"
+
+func TestInlineInterpolate(t *testing.T) {
+	got, err := InterpolateQuery("SELECT * FROM t WHERE a = $1 AND b IN $2", Inline{Value: "it's"}, Inline{Value: []int{1, 2}})
+	if err != nil {
+		t.Fatalf("InterpolateQuery() error = %v", err)
+	}
+	if expected := "SELECT * FROM t WHERE a = 'it''s' AND b IN (1,2)"; got != expected {
+		t.Errorf("InterpolateQuery() = %v, want %v", got, expected)
+	}
+}
@@ -0,0 +1,168 @@
+package informix
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// IntervalField is a field of an Informix INTERVAL qualifier.
+type IntervalField int
+
+const (
+	IntervalDay IntervalField = iota + 1
+	IntervalHour
+	IntervalMinute
+	IntervalSecond
+)
+
+func (f IntervalField) String() string {
+	switch f {
+	case IntervalDay:
+		return "DAY"
+	case IntervalHour:
+		return "HOUR"
+	case IntervalMinute:
+		return "MINUTE"
+	case IntervalSecond:
+		return "SECOND"
+	}
+	return fmt.Sprintf("IntervalField(%d)", int(f))
+}
+
+// unit returns the duration of one unit of f.
+func (f IntervalField) unit() time.Duration {
+	switch f {
+	case IntervalDay:
+		return 24 * time.Hour
+	case IntervalHour:
+		return time.Hour
+	case IntervalMinute:
+		return time.Minute
+	}
+	return time.Second
+}
+
+// max returns the largest value f can hold when it is not the first
+// field of a qualifier.
+func (f IntervalField) max() uint64 {
+	if f == IntervalHour {
+		return 23
+	}
+	return 59
+}
+
+// defaultIntervalPrecision is the number of digits Informix allows in
+// the first field of an INTERVAL qualifier when none is given.
+const defaultIntervalPrecision = 2
+
+// Interval formats a time.Duration as an Informix INTERVAL literal,
+// for example INTERVAL(1 02:03:04) DAY TO SECOND. Parts of the
+// duration smaller than the To field are truncated.
+type Interval struct {
+	Duration time.Duration
+
+	// From and To are the first and last fields of the qualifier.
+	// They default to IntervalDay and IntervalSecond.
+	From, To IntervalField
+
+	// Precision is the number of digits of the From field, between 1
+	// and 9. It defaults to 2, as it does in Informix.
+	Precision int
+
+	// Clamp makes a Duration that does not fit in Precision digits
+	// render as the largest interval that fits, instead of failing.
+	Clamp bool
+}
+
+// literal returns iv as an Informix INTERVAL literal.
+func (iv Interval) literal() (string, error) {
+	from, to := iv.From, iv.To
+	if from == 0 {
+		from = IntervalDay
+	}
+	if to == 0 {
+		to = IntervalSecond
+	}
+	if from < IntervalDay || to > IntervalSecond || from > to {
+		return "", fmt.Errorf("invalid INTERVAL qualifier %v TO %v", from, to)
+	}
+	prec := iv.Precision
+	if prec == 0 {
+		prec = defaultIntervalPrecision
+	}
+	if prec < 1 || prec > 9 {
+		return "", fmt.Errorf("invalid INTERVAL precision %d", prec)
+	}
+
+	sign := ""
+	mag := uint64(iv.Duration)
+	if iv.Duration < 0 {
+		sign = "-"
+		mag = uint64(-(iv.Duration + 1)) + 1
+	}
+
+	limit := uint64(1)
+	for i := 0; i < prec; i++ {
+		limit *= 10
+	}
+	lead := mag / uint64(from.unit())
+	overflow := lead >= limit
+	if overflow && !iv.Clamp {
+		return "", fmt.Errorf("interval %v overflows %v(%d)", iv.Duration, from, prec)
+	}
+
+	var b strings.Builder
+	b.WriteString("INTERVAL(")
+	b.WriteString(sign)
+	for f := from; f <= to; f++ {
+		var v uint64
+		switch {
+		case f == from && overflow:
+			v = limit - 1
+		case f == from:
+			v = lead
+		case overflow:
+			v = f.max()
+		default:
+			v = mag % uint64((f - 1).unit()) / uint64(f.unit())
+		}
+		switch {
+		case f == from:
+			fmt.Fprintf(&b, "%d", v)
+		case f == IntervalHour:
+			fmt.Fprintf(&b, " %02d", v)
+		default:
+			fmt.Fprintf(&b, ":%02d", v)
+		}
+	}
+	b.WriteString(") ")
+	b.WriteString(from.String())
+	if prec != defaultIntervalPrecision {
+		fmt.Fprintf(&b, "(%d)", prec)
+	}
+	b.WriteString(" TO ")
+	b.WriteString(to.String())
+	return b.String(), nil
+}
+
+// ColumnInterval is an argument that renders as a column shifted by
+// an interval, for example "created" + INTERVAL(30) DAY TO DAY, as used
+// in audit defaults and triggers.
+type ColumnInterval struct {
+	Column   Column
+	Interval Interval
+}
+
+// expression returns ci as a SQL expression.
+func (ci ColumnInterval) expression() (string, error) {
+	col, err := QuoteIdentifier(string(ci.Column))
+	if err != nil {
+		return "", err
+	}
+	iv, err := ci.Interval.literal()
+	if err != nil {
+		return "", err
+	}
+	return col + " + " + iv, nil
+}
@@ -0,0 +1,133 @@
+package informix
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInterval(t *testing.T) {
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name     string
+		input    Interval
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "day to second",
+			input:    Interval{Duration: day + 2*time.Hour + 3*time.Minute + 4*time.Second},
+			expected: "INTERVAL(1 02:03:04) DAY TO SECOND",
+		},
+		{
+			name:     "negative",
+			input:    Interval{Duration: -(90 * time.Minute)},
+			expected: "INTERVAL(-0 01:30:00) DAY TO SECOND",
+		},
+		{
+			name:     "hour to minute",
+			input:    Interval{Duration: 30*time.Hour + 15*time.Minute + 59*time.Second, From: IntervalHour, To: IntervalMinute},
+			expected: "INTERVAL(30:15) HOUR TO MINUTE",
+		},
+		{
+			name:     "fits in default precision",
+			input:    Interval{Duration: 99 * day},
+			expected: "INTERVAL(99 00:00:00) DAY TO SECOND",
+		},
+		{
+			name:    "overflows default precision",
+			input:   Interval{Duration: 100 * day},
+			wantErr: true,
+		},
+		{
+			name:     "fits in wider precision",
+			input:    Interval{Duration: 400 * day, Precision: 3},
+			expected: "INTERVAL(400 00:00:00) DAY(3) TO SECOND",
+		},
+		{
+			name:    "overflows limited precision",
+			input:   Interval{Duration: 10 * day, Precision: 1},
+			wantErr: true,
+		},
+		{
+			name:     "overflow clamped",
+			input:    Interval{Duration: 10 * day, Precision: 1, Clamp: true},
+			expected: "INTERVAL(9 23:59:59) DAY(1) TO SECOND",
+		},
+		{
+			name:    "invalid qualifier",
+			input:   Interval{From: IntervalSecond, To: IntervalDay},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatArgument(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("formatArgument() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestColumnInterval(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    ColumnInterval
+		expected string
+		wantErr  bool
+	}{
+		{
+			name: "column plus days",
+			input: ColumnInterval{
+				Column:   "created",
+				Interval: Interval{Duration: 30 * 24 * time.Hour, To: IntervalDay},
+			},
+			expected: `"created" + INTERVAL(30) DAY TO DAY`,
+		},
+		{
+			name: "column minus hours",
+			input: ColumnInterval{
+				Column:   "expires",
+				Interval: Interval{Duration: -2 * time.Hour, From: IntervalHour, To: IntervalHour},
+			},
+			expected: `"expires" + INTERVAL(-2) HOUR TO HOUR`,
+		},
+		{
+			name: "invalid column",
+			input: ColumnInterval{
+				Interval: Interval{Duration: time.Hour},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid interval",
+			input: ColumnInterval{
+				Column:   "created",
+				Interval: Interval{Duration: 1000 * 24 * time.Hour},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := InterpolateQuery("UPDATE t SET expires = $1", tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("InterpolateQuery() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if expected := "UPDATE t SET expires = " + tt.expected; got != expected {
+				t.Errorf("InterpolateQuery() = %v, want %v", got, expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package informix
+
+import "strings"
+
+// likeEscaper escapes the LIKE escape character and wildcards.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// EscapeLike escapes the LIKE wildcards % and _ in s, and the escape
+// character itself, with a backslash. Backslash is the default LIKE
+// escape character in Informix, Postgres and MySQL, so no ESCAPE
+// clause is needed. The result is not quoted.
+func EscapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+// LikeValue is a string argument that is matched literally by LIKE.
+// Its wildcards are escaped with EscapeLike before it is quoted, so
+// WHERE name LIKE $1 with a LikeValue argument only matches the value
+// itself.
+type LikeValue string
+
+// Contains returns a quoted LIKE pattern, for the default settings,
+// that matches any string containing term, such as '%50\%%' for 50%.
+// The wildcards in term are escaped with EscapeLike, so that only the
+// added % wildcards match anything. As with EscapeLike, backslash is
+// the escape character and no ESCAPE clause is needed. The pattern is
+// quoted like any other string argument, so it fails for control
+// characters under RejectControlChars.
+func Contains(term string) (string, error) {
+	return defaultInterpolator().Contains(term)
+}
+
+// StartsWith is like Contains, but matches strings that start with
+// term.
+func StartsWith(term string) (string, error) {
+	return defaultInterpolator().StartsWith(term)
+}
+
+// EndsWith is like Contains, but matches strings that end with term.
+func EndsWith(term string) (string, error) {
+	return defaultInterpolator().EndsWith(term)
+}
+
+// Contains is like the package level Contains, but quotes the pattern
+// using the settings of ip.
+func (ip *Interpolator) Contains(term string) (string, error) {
+	return ip.quoteString("%" + EscapeLike(term) + "%")
+}
+
+// StartsWith is like the package level StartsWith, but quotes the
+// pattern using the settings of ip.
+func (ip *Interpolator) StartsWith(term string) (string, error) {
+	return ip.quoteString(EscapeLike(term) + "%")
+}
+
+// EndsWith is like the package level EndsWith, but quotes the pattern
+// using the settings of ip.
+func (ip *Interpolator) EndsWith(term string) (string, error) {
+	return ip.quoteString("%" + EscapeLike(term))
+}
@@ -0,0 +1,175 @@
+package informix
+
+import "testing"
+
+func TestEscapeLike(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "no wildcards",
+			input:    "hello",
+			expected: "hello",
+		},
+		{
+			name:     "percent and underscore",
+			input:    "50%_off",
+			expected: `50\%\_off`,
+		},
+		{
+			name:     "backslash",
+			input:    `a\b`,
+			expected: `a\\b`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EscapeLike(tt.input)
+			if got != tt.expected {
+				t.Errorf("EscapeLike() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLikeValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  Dialect
+		args     []interface{}
+		expected string
+	}{
+		{
+			name:     "percent and underscore",
+			dialect:  Informix,
+			args:     []interface{}{LikeValue("100%_sure")},
+			expected: `SELECT * FROM t WHERE name LIKE '100\%\_sure'`,
+		},
+		{
+			name:     "quote",
+			dialect:  Informix,
+			args:     []interface{}{LikeValue("O'_")},
+			expected: `SELECT * FROM t WHERE name LIKE 'O''\_'`,
+		},
+		{
+			name:     "mysql escapes the escape character",
+			dialect:  MySQL,
+			args:     []interface{}{LikeValue("5%")},
+			expected: `SELECT * FROM t WHERE name LIKE '5\\%'`,
+		},
+		{
+			name:     "plain string is not escaped",
+			dialect:  Informix,
+			args:     []interface{}{"5%"},
+			expected: `SELECT * FROM t WHERE name LIKE '5%'`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip := &Interpolator{Dialect: tt.dialect}
+			got, err := ip.InterpolateQuery("SELECT * FROM t WHERE name LIKE $1", tt.args...)
+			if err != nil {
+				t.Fatalf("InterpolateQuery() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("InterpolateQuery() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLikePatterns(t *testing.T) {
+	tests := []struct {
+		name     string
+		ip       Interpolator
+		fn       func(ip *Interpolator, term string) (string, error)
+		term     string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "contains",
+			fn:       (*Interpolator).Contains,
+			term:     "50%_off",
+			expected: `'%50\%\_off%'`,
+		},
+		{
+			name:     "starts with",
+			fn:       (*Interpolator).StartsWith,
+			term:     "50%_off",
+			expected: `'50\%\_off%'`,
+		},
+		{
+			name:     "ends with",
+			fn:       (*Interpolator).EndsWith,
+			term:     "50%_off",
+			expected: `'%50\%\_off'`,
+		},
+		{
+			name:     "contains quote",
+			ip:       Interpolator{Dialect: Informix},
+			fn:       (*Interpolator).Contains,
+			term:     "O'_",
+			expected: `'%O''\_%'`,
+		},
+		{
+			name:     "mysql contains",
+			ip:       Interpolator{Dialect: MySQL},
+			fn:       (*Interpolator).Contains,
+			term:     "5%",
+			expected: `'%5\\%%'`,
+		},
+		{
+			name:     "empty term",
+			fn:       (*Interpolator).Contains,
+			term:     "",
+			expected: `'%%'`,
+		},
+		{
+			name:     "stripped control characters",
+			ip:       Interpolator{ControlChars: StripControlChars},
+			fn:       (*Interpolator).StartsWith,
+			term:     "a\x00b",
+			expected: `'ab%'`,
+		},
+		{
+			name:    "rejected control characters",
+			ip:      Interpolator{ControlChars: RejectControlChars},
+			fn:      (*Interpolator).EndsWith,
+			term:    "a\x00b",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fn(&tt.ip, tt.term)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("pattern error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("pattern = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+
+	for _, tt := range []struct {
+		name     string
+		fn       func(string) (string, error)
+		term     string
+		expected string
+	}{
+		{name: "Contains", fn: Contains, term: "a_b", expected: `'%a\_b%'`},
+		{name: "StartsWith", fn: StartsWith, term: "a%", expected: `'a\%%'`},
+		{name: "EndsWith", fn: EndsWith, term: "_", expected: `'%\_'`},
+	} {
+		got, err := tt.fn(tt.term)
+		if err != nil || got != tt.expected {
+			t.Errorf("%s() = %v, %v, want %v", tt.name, got, err, tt.expected)
+		}
+	}
+}
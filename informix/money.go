@@ -0,0 +1,21 @@
+package informix
+
+import "fmt"
+
+// Money is an amount of money held in cents. It is rendered as an
+// Informix MONEY literal with two decimal places, such as 1234.56,
+// without currency symbol or thousands separators.
+type Money struct {
+	Cents int64
+}
+
+// literal returns m as a decimal literal.
+func (m Money) literal() string {
+	sign := ""
+	mag := uint64(m.Cents)
+	if m.Cents < 0 {
+		sign = "-"
+		mag = uint64(-(m.Cents + 1)) + 1
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, mag/100, mag%100)
+}
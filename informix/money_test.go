@@ -0,0 +1,209 @@
+package informix
+
+import (
+	"database/sql/driver"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestMoney(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    Money
+		expected string
+	}{
+		{
+			name:     "positive",
+			input:    Money{Cents: 123456},
+			expected: "1234.56",
+		},
+		{
+			name:     "negative",
+			input:    Money{Cents: -123456},
+			expected: "-1234.56",
+		},
+		{
+			name:     "zero",
+			input:    Money{},
+			expected: "0.00",
+		},
+		{
+			name:     "sub-unit",
+			input:    Money{Cents: 5},
+			expected: "0.05",
+		},
+		{
+			name:     "negative sub-unit",
+			input:    Money{Cents: -5},
+			expected: "-0.05",
+		},
+		{
+			name:     "minimum",
+			input:    Money{Cents: math.MinInt64},
+			expected: "-92233720368547758.08",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatArgument(tt.input)
+			if err != nil {
+				t.Fatalf("formatArgument() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// fakeDecimal mirrors the methods of github.com/shopspring/decimal.Decimal
+// that are used to recognise exact decimals.
+type fakeDecimal struct {
+	coef *big.Int
+	exp  int32
+}
+
+func (d fakeDecimal) Coefficient() *big.Int {
+	return new(big.Int).Set(d.coef)
+}
+
+func (d fakeDecimal) Exponent() int32 {
+	return d.exp
+}
+
+func (d fakeDecimal) String() string {
+	s := d.coef.String()
+	if d.exp >= 0 {
+		return s + strings.Repeat("0", int(d.exp))
+	}
+	sign := ""
+	if s[0] == '-' {
+		sign, s = "-", s[1:]
+	}
+	scale := int(-d.exp)
+	if len(s) <= scale {
+		s = strings.Repeat("0", scale-len(s)+1) + s
+	}
+	return sign + s[:len(s)-scale] + "." + s[len(s)-scale:]
+}
+
+// Value returns the decimal as a string, as shopspring/decimal does.
+func (d fakeDecimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+func TestDecimal(t *testing.T) {
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+
+	tests := []struct {
+		name     string
+		input    fakeDecimal
+		expected string
+	}{
+		{
+			name:     "fraction",
+			input:    fakeDecimal{coef: big.NewInt(314), exp: -2},
+			expected: "3.14",
+		},
+		{
+			name:     "negative",
+			input:    fakeDecimal{coef: big.NewInt(-5), exp: -3},
+			expected: "-0.005",
+		},
+		{
+			name:     "integer",
+			input:    fakeDecimal{coef: big.NewInt(12), exp: 2},
+			expected: "1200",
+		},
+		{
+			name:     "precision preserved",
+			input:    fakeDecimal{coef: huge, exp: -20},
+			expected: "1234567890.12345678901234567890",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := InterpolateQuery("SELECT $1", tt.input)
+			if err != nil {
+				t.Fatalf("InterpolateQuery() error = %v", err)
+			}
+			if expected := "SELECT " + tt.expected; got != expected {
+				t.Errorf("InterpolateQuery() = %v, want %v", got, expected)
+			}
+		})
+	}
+}
+
+func TestFormatRat(t *testing.T) {
+	third := big.NewRat(1, 3)
+	var none *big.Rat
+
+	tests := []struct {
+		name     string
+		scale    int
+		arg      interface{}
+		expected string
+	}{
+		{
+			name:     "default scale",
+			arg:      third,
+			expected: "0.33",
+		},
+		{
+			name:     "scale 2",
+			scale:    2,
+			arg:      third,
+			expected: "0.33",
+		},
+		{
+			name:     "scale 4",
+			scale:    4,
+			arg:      third,
+			expected: "0.3333",
+		},
+		{
+			name:     "negative",
+			arg:      big.NewRat(-7, 4),
+			expected: "-1.75",
+		},
+		{
+			name:     "negative rounded",
+			scale:    1,
+			arg:      big.NewRat(-7, 4),
+			expected: "-1.8",
+		},
+		{
+			name:     "no decimal places",
+			scale:    -1,
+			arg:      big.NewRat(5, 2),
+			expected: "3",
+		},
+		{
+			name:     "nil",
+			arg:      none,
+			expected: "NULL",
+		},
+		{
+			name:     "in list",
+			arg:      []*big.Rat{third, nil},
+			expected: "(0.33,NULL)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip := &Interpolator{RatScale: tt.scale}
+			got, err := ip.formatArgument(tt.arg)
+			if err != nil {
+				t.Fatalf("formatArgument() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package informix
+
+import "fmt"
+
+// InterpolateNamed is like InterpolateQuery, but takes named
+// placeholders such as :user_id, whose values are looked up in args.
+func InterpolateNamed(query string, args map[string]interface{}) (string, error) {
+	return defaultInterpolator().InterpolateNamed(query, args)
+}
+
+// InterpolateNamed is like the package level InterpolateNamed, but
+// renders values using the settings of ip. Placeholders start with
+// ip.NamePrefix.
+func (ip *Interpolator) InterpolateNamed(query string, args map[string]interface{}) (string, error) {
+	prefix := ip.NamePrefix
+	if prefix == 0 {
+		prefix = ':'
+	}
+	phs, err := scanNamedPlaceholders(query, prefix)
+	if err != nil {
+		return "", err
+	}
+	return replacePlaceholders(query, phs, func(ph placeholder) (string, error) {
+		arg, ok := args[ph.name]
+		if !ok {
+			if ip.StrictArgs {
+				return "", fmt.Errorf("%w: placeholder %s has no argument", ErrTooFewArgs, query[ph.start:ph.end])
+			}
+			return query[ph.start:ph.end], nil
+		}
+		s, err := ip.formatArgument(arg)
+		if err != nil {
+			return "", fmt.Errorf("argument %s: %w", ph.name, err)
+		}
+		return s, nil
+	})
+}
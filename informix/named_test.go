@@ -0,0 +1,98 @@
+package informix
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestInterpolateNamed(t *testing.T) {
+	args := map[string]interface{}{
+		"user_id": 42,
+		"name":    "O'Connor",
+	}
+
+	tests := []struct {
+		name     string
+		prefix   byte
+		strict   bool
+		query    string
+		expected string
+		wantErr  error
+	}{
+		{
+			name:     "colon",
+			query:    "SELECT * FROM users WHERE id = :user_id AND name = :name",
+			expected: "SELECT * FROM users WHERE id = 42 AND name = 'O''Connor'",
+		},
+		{
+			name:     "at",
+			prefix:   '@',
+			query:    "SELECT * FROM users WHERE id = @user_id AND name = @name",
+			expected: "SELECT * FROM users WHERE id = 42 AND name = 'O''Connor'",
+		},
+		{
+			name:     "reused name",
+			query:    "SELECT * FROM t WHERE a = :user_id OR b = :user_id",
+			expected: "SELECT * FROM t WHERE a = 42 OR b = 42",
+		},
+		{
+			name:     "cast is not a placeholder",
+			query:    "SELECT id::text FROM users WHERE id = :user_id",
+			expected: "SELECT id::text FROM users WHERE id = 42",
+		},
+		{
+			name:     "email in literal",
+			prefix:   '@',
+			query:    "SELECT * FROM users WHERE email = 'x@name' AND id = @user_id",
+			expected: "SELECT * FROM users WHERE email = 'x@name' AND id = 42",
+		},
+		{
+			name:     "email outside literal",
+			prefix:   '@',
+			query:    "SELECT user@name FROM t WHERE id = @user_id",
+			expected: "SELECT user@name FROM t WHERE id = 42",
+		},
+		{
+			name:     "system variable",
+			prefix:   '@',
+			query:    "SELECT @@ROWCOUNT, @name",
+			expected: "SELECT @@ROWCOUNT, 'O''Connor'",
+		},
+		{
+			name:     "other prefix is ignored",
+			prefix:   '@',
+			query:    "SELECT :name, @name",
+			expected: "SELECT :name, 'O''Connor'",
+		},
+		{
+			name:     "comment",
+			query:    "SELECT :name -- :user_id",
+			expected: "SELECT 'O''Connor' -- :user_id",
+		},
+		{
+			name:     "missing lenient",
+			query:    "SELECT :missing",
+			expected: "SELECT :missing",
+		},
+		{
+			name:    "missing strict",
+			strict:  true,
+			query:   "SELECT :missing",
+			wantErr: ErrTooFewArgs,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip := &Interpolator{NamePrefix: tt.prefix, StrictArgs: tt.strict}
+			got, err := ip.InterpolateNamed(tt.query, args)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("InterpolateNamed() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.expected {
+				t.Errorf("InterpolateNamed() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
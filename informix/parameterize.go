@@ -0,0 +1,177 @@
+package informix
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Parameterize prepares query for execution with bound parameters
+// rather than inlined literals. It rewrites every placeholder to the
+// ODBC ? marker and returns the arguments, converted to driver.Value,
+// in the order the markers appear. An argument referenced by several
+// $n placeholders is repeated.
+//
+// Arguments are told apart as InterpolateQuery tells them apart, and
+// each is bound as the value its literal is written from: types stored
+// as text, such as LikeValue, net.IP, JSON documents and values with a
+// MarshalText or String method, as that text; exact numbers, such as
+// Money and *big.Rat, as their decimal text; and DateOnly and TimeOnly
+// as a time.Time. A slice argument, other than []byte and types with
+// their own SQL form, is expanded into a parenthesized list with one
+// marker per element, (?,?,?), where InterpolateQuery writes the list
+// (1,2,3), so that the same query, such as x IN $1, serves both. An
+// empty slice is written as (NULL), so that x IN $1 matches no rows,
+// unless the EmptySlice option asks for an error or a collection
+// literal. A []interface{} is not a list but an array, as it is for
+// InterpolateQuery, and is written as the literal ARRAY[1,'a']. Row,
+// Tuples and Cast arguments keep their SQL form around markers for
+// their values, as in ROW(?,?) and CAST(? AS INTEGER). Arguments that only have a SQL form take no marker and are written
+// into the query as literals: Inline, Raw, Column, Interval and Serial
+// arguments, collection literals and types with a registered
+// formatter. Prefer it over InterpolateQuery whenever the driver can
+// bind values; keep interpolation for logging.
+func Parameterize(query string, args ...interface{}) (string, []driver.Value, error) {
+	return defaultInterpolator().Parameterize(query, args...)
+}
+
+// Parameterize is like the package level Parameterize, but uses the
+// settings of ip.
+func (ip *Interpolator) Parameterize(query string, args ...interface{}) (string, []driver.Value, error) {
+	phs, err := ip.scanPlaceholders(query)
+	if err != nil {
+		return "", nil, err
+	}
+	if !ip.AllowMixedStyles {
+		if err := checkMixedStyles(phs); err != nil {
+			return "", nil, err
+		}
+	}
+	assignIndexes(phs, ip.dollarBase())
+	for _, ph := range phs {
+		if ph.index < 0 || ph.index >= len(args) {
+			return "", nil, newQueryError(query, ph.start, fmt.Errorf("%w: placeholder %s has no argument, got %d", ErrTooFewArgs, query[ph.start:ph.end], len(args)))
+		}
+	}
+	if i := unusedArgument(phs, len(args)); i >= 0 {
+		return "", nil, fmt.Errorf("%w: argument %d is not used, got %d", ErrTooManyArgs, i+1, len(args))
+	}
+	values := make([]driver.Value, 0, len(phs))
+
+	rewritten, err := replacePlaceholders(query, phs, func(ph placeholder) (string, error) {
+		m, err := ip.bind(args[ph.index], &values)
+		if err != nil {
+			return "", fmt.Errorf("argument %d: %w", ph.index+1, err)
+		}
+		return m, nil
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return rewritten, values, nil
+}
+
+// bind returns the text that stands for arg in a parameterized query,
+// appending the values bound for it to values. arg is classified as
+// formatArgument classifies it: a value with a SQL literal is bound
+// to a ? marker, as the string, []byte or time.Time the literal is
+// made from; lists, rows, tuples and casts keep their SQL form around
+// markers for their values; and what can only be written as SQL text,
+// such as a Column or an Inline argument, is written as that text.
+func (ip *Interpolator) bind(arg interface{}, values *[]driver.Value) (string, error) {
+	k, v, err := ip.classify(arg, nil)
+	if err != nil {
+		return "", err
+	}
+
+	switch k {
+	case kindRaw:
+		return v.(string), nil
+
+	case kindInline:
+		return ip.formatArgument(v)
+
+	case kindCollection:
+		return ip.formatCollection(reflect.ValueOf(v), false)
+
+	case kindList:
+		rv := reflect.ValueOf(v)
+		if rv.Len() == 0 {
+			// () is a syntax error on most servers, so the default
+			// mode binds an empty slice as (NULL), which matches no
+			// rows
+			if ip.EmptySlice == EmptyParens {
+				return "(NULL)", nil
+			}
+			return ip.formatList(rv)
+		}
+		markers := make([]string, rv.Len())
+		for i := range markers {
+			m, err := ip.bind(rv.Index(i).Interface(), values)
+			if err != nil {
+				return "", fmt.Errorf("element %d: %w", i, err)
+			}
+			markers[i] = m
+		}
+		return "(" + strings.Join(markers, ",") + ")", nil
+
+	case kindRow:
+		fields, ok, err := v.(Row).fields()
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			*values = append(*values, nil)
+			return "?", nil
+		}
+		markers := make([]string, len(fields))
+		for i, f := range fields {
+			m, err := ip.bind(f.Interface(), values)
+			if err != nil {
+				return "", err
+			}
+			markers[i] = m
+		}
+		return "ROW(" + strings.Join(markers, ",") + ")", nil
+
+	case kindTuples:
+		t := v.(Tuples)
+		if err := t.check(); err != nil {
+			return "", err
+		}
+		tuples := make([]string, len(t))
+		for i, tuple := range t {
+			markers := make([]string, len(tuple))
+			for j, elem := range tuple {
+				m, err := ip.bind(elem, values)
+				if err != nil {
+					return "", fmt.Errorf("tuple %d, value %d: %w", i, j, err)
+				}
+				markers[j] = m
+			}
+			tuples[i] = "(" + strings.Join(markers, ",") + ")"
+		}
+		return "(" + strings.Join(tuples, ",") + ")", nil
+
+	case kindCast:
+		c := v.(CastValue)
+		if err := checkSQLType(c.Type); err != nil {
+			return "", err
+		}
+		m, err := ip.bind(c.Value, values)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("CAST(%s AS %s)", m, c.Type), nil
+	}
+
+	// What is left is a single value: NULL, or a bool, number,
+	// string, []byte or time.Time
+	dv, err := driver.DefaultParameterConverter.ConvertValue(v)
+	if err != nil {
+		return "", err
+	}
+	*values = append(*values, dv)
+	return "?", nil
+}
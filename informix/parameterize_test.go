@@ -0,0 +1,625 @@
+package informix
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParameterize(t *testing.T) {
+	now := time.Date(2024, 2, 12, 15, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		query          string
+		args           []interface{}
+		expectedQuery  string
+		expectedValues []driver.Value
+		wantErr        bool
+	}{
+		{
+			name:           "dollar placeholders",
+			query:          "SELECT * FROM users WHERE id = $1 AND name = $2",
+			args:           []interface{}{123, "O'Connor"},
+			expectedQuery:  "SELECT * FROM users WHERE id = ? AND name = ?",
+			expectedValues: []driver.Value{int64(123), "O'Connor"},
+		},
+		{
+			name:           "question placeholders",
+			query:          "INSERT INTO t (a, b, c) VALUES (?, ?, ?)",
+			args:           []interface{}{now, []byte{1, 2}, nil},
+			expectedQuery:  "INSERT INTO t (a, b, c) VALUES (?, ?, ?)",
+			expectedValues: []driver.Value{now, []byte{1, 2}, nil},
+		},
+		{
+			name:           "reused placeholder",
+			query:          "SELECT * FROM t WHERE a = $2 OR b = $1 OR c = $2",
+			args:           []interface{}{1, "x"},
+			expectedQuery:  "SELECT * FROM t WHERE a = ? OR b = ? OR c = ?",
+			expectedValues: []driver.Value{"x", int64(1), "x"},
+		},
+		{
+			name:           "valuer",
+			query:          "SELECT * FROM t WHERE a = $1",
+			args:           []interface{}{customValuer{value: "custom"}},
+			expectedQuery:  "SELECT * FROM t WHERE a = ?",
+			expectedValues: []driver.Value{"custom"},
+		},
+		{
+			name:           "slice expansion",
+			query:          "SELECT * FROM t WHERE id IN $1 AND name = $2",
+			args:           []interface{}{[]int{1, 2, 3}, "x"},
+			expectedQuery:  "SELECT * FROM t WHERE id IN (?,?,?) AND name = ?",
+			expectedValues: []driver.Value{int64(1), int64(2), int64(3), "x"},
+		},
+		{
+			name:           "empty slice",
+			query:          "SELECT * FROM t WHERE id IN $1 AND name = $2",
+			args:           []interface{}{[]int{}, "x"},
+			expectedQuery:  "SELECT * FROM t WHERE id IN (NULL) AND name = ?",
+			expectedValues: []driver.Value{"x"},
+		},
+		{
+			name:           "nil slice",
+			query:          "SELECT * FROM t WHERE id IN ?",
+			args:           []interface{}{[]string(nil)},
+			expectedQuery:  "SELECT * FROM t WHERE id IN ?",
+			expectedValues: []driver.Value{nil},
+		},
+		{
+			name:           "slice between scalars",
+			query:          "SELECT * FROM t WHERE a = ? AND id IN ? AND b = ?",
+			args:           []interface{}{1, []int64{2, 3}, 4},
+			expectedQuery:  "SELECT * FROM t WHERE a = ? AND id IN (?,?) AND b = ?",
+			expectedValues: []driver.Value{int64(1), int64(2), int64(3), int64(4)},
+		},
+		{
+			name:           "reused slice",
+			query:          "SELECT * FROM t WHERE a IN $1 OR b IN $1",
+			args:           []interface{}{[]string{"x", "y"}},
+			expectedQuery:  "SELECT * FROM t WHERE a IN (?,?) OR b IN (?,?)",
+			expectedValues: []driver.Value{"x", "y", "x", "y"},
+		},
+		{
+			name:           "slice of valuers",
+			query:          "SELECT * FROM t WHERE a IN ?",
+			args:           []interface{}{[]customValuer{{value: "a"}, {value: "b"}}},
+			expectedQuery:  "SELECT * FROM t WHERE a IN (?,?)",
+			expectedValues: []driver.Value{"a", "b"},
+		},
+		{
+			name:           "bytes are not expanded",
+			query:          "SELECT * FROM t WHERE a = ?",
+			args:           []interface{}{[]byte{1, 2}},
+			expectedQuery:  "SELECT * FROM t WHERE a = ?",
+			expectedValues: []driver.Value{[]byte{1, 2}},
+		},
+		{
+			name:           "inlined constant",
+			query:          "SELECT * FROM t WHERE status = $1 AND owner = $2",
+			args:           []interface{}{Inline{Value: "active"}, "O'Connor"},
+			expectedQuery:  "SELECT * FROM t WHERE status = 'active' AND owner = ?",
+			expectedValues: []driver.Value{"O'Connor"},
+		},
+		{
+			name:           "inlined and bound question markers",
+			query:          "UPDATE t SET changed = ?, kind = ? WHERE id = ? AND x IN ?",
+			args:           []interface{}{Current, Inline{Value: 3}, 42, Inline{Value: []int{1, 2}}},
+			expectedQuery:  "UPDATE t SET changed = CURRENT, kind = 3 WHERE id = ? AND x IN (1,2)",
+			expectedValues: []driver.Value{int64(42)},
+		},
+		{
+			name:           "inlined nil",
+			query:          "SELECT * FROM t WHERE a = $1 OR b = $2",
+			args:           []interface{}{Inline{}, nil},
+			expectedQuery:  "SELECT * FROM t WHERE a = NULL OR b = ?",
+			expectedValues: []driver.Value{nil},
+		},
+		{
+			name:    "inlined unsupported value",
+			query:   "SELECT * FROM t WHERE a = $1",
+			args:    []interface{}{Inline{Value: complex(1, 2)}},
+			wantErr: true,
+		},
+		{
+			name:    "too few arguments",
+			query:   "SELECT * FROM t WHERE a = $1 AND b = $2",
+			args:    []interface{}{1},
+			wantErr: true,
+		},
+		{
+			name:    "too many arguments",
+			query:   "SELECT * FROM t WHERE a = $1",
+			args:    []interface{}{1, 2},
+			wantErr: true,
+		},
+		{
+			name:    "unused argument in gap",
+			query:   "SELECT * FROM t WHERE a = $1 AND b = $3",
+			args:    []interface{}{1, 2, 3},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, values, err := Parameterize(tt.query, tt.args...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Parameterize() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.expectedQuery {
+				t.Errorf("Parameterize() query = %v, want %v", got, tt.expectedQuery)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(values, tt.expectedValues) {
+				t.Errorf("Parameterize() values = %#v, want %#v", values, tt.expectedValues)
+			}
+		})
+	}
+}
+
+func TestParameterizeEmptySlice(t *testing.T) {
+	query := "SELECT * FROM t WHERE id IN $1"
+
+	tests := []struct {
+		name     string
+		mode     EmptySliceMode
+		expected string
+		wantErr  bool
+	}{
+		{name: "parens", mode: EmptyParens, expected: "SELECT * FROM t WHERE id IN (NULL)"},
+		{name: "null", mode: EmptyNull, expected: "SELECT * FROM t WHERE id IN (NULL)"},
+		{name: "error", mode: EmptyError, wantErr: true},
+		{name: "collection", mode: EmptyCollection, expected: "SELECT * FROM t WHERE id IN ARRAY[]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip := &Interpolator{EmptySlice: tt.mode}
+			got, values, err := ip.Parameterize(query, []int{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parameterize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("Parameterize() = %v, want %v", got, tt.expected)
+			}
+			if len(values) != 0 {
+				t.Errorf("Parameterize() values = %#v, want none", values)
+			}
+		})
+	}
+}
+
+func TestParameterizeTypes(t *testing.T) {
+	RegisterFormatter(reflect.TypeOf(point{}), func(v interface{}) (string, error) {
+		p := v.(point)
+		return fmt.Sprintf("ST_Point(%g, %g)", p.X, p.Y), nil
+	})
+	defer RegisterFormatter(reflect.TypeOf(point{}), nil)
+	RegisterEnum(map[shade]string{red: "red", green: "green"})
+	defer RegisterEnum[shade, string](nil)
+
+	day := time.Date(2024, 2, 12, 15, 4, 5, 0, time.UTC)
+	like := LikeValue("50%_x")
+
+	tests := []struct {
+		name           string
+		arg            interface{}
+		expectedQuery  string
+		expectedValues []driver.Value
+		wantErr        bool
+	}{
+		{
+			name:           "like value",
+			arg:            like,
+			expectedQuery:  "SELECT * FROM t WHERE a = ?",
+			expectedValues: []driver.Value{`50\%\_x`},
+		},
+		{
+			name:           "like value pointer",
+			arg:            &like,
+			expectedQuery:  "SELECT * FROM t WHERE a = ?",
+			expectedValues: []driver.Value{`50\%\_x`},
+		},
+		{
+			name:           "runes",
+			arg:            Runes("abc"),
+			expectedQuery:  "SELECT * FROM t WHERE a = ?",
+			expectedValues: []driver.Value{"abc"},
+		},
+		{
+			name:           "int32 list",
+			arg:            []int32{1, 2, 39},
+			expectedQuery:  "SELECT * FROM t WHERE a = (?,?,?)",
+			expectedValues: []driver.Value{int64(1), int64(2), int64(39)},
+		},
+		{
+			name:           "array",
+			arg:            [2]string{"a", "b"},
+			expectedQuery:  "SELECT * FROM t WHERE a = (?,?)",
+			expectedValues: []driver.Value{"a", "b"},
+		},
+		{
+			name:           "byte array",
+			arg:            [2]byte{1, 2},
+			expectedQuery:  "SELECT * FROM t WHERE a = ?",
+			expectedValues: []driver.Value{[]byte{1, 2}},
+		},
+		{
+			name:           "char",
+			arg:            Char('A'),
+			expectedQuery:  "SELECT * FROM t WHERE a = ?",
+			expectedValues: []driver.Value{"A"},
+		},
+		{
+			name:           "text",
+			arg:            Text("abc"),
+			expectedQuery:  "SELECT * FROM t WHERE a = ?",
+			expectedValues: []driver.Value{"abc"},
+		},
+		{
+			name:           "ip",
+			arg:            net.ParseIP("10.0.0.1"),
+			expectedQuery:  "SELECT * FROM t WHERE a = ?",
+			expectedValues: []driver.Value{"10.0.0.1"},
+		},
+		{
+			name:           "nil ip",
+			arg:            net.IP(nil),
+			expectedQuery:  "SELECT * FROM t WHERE a = ?",
+			expectedValues: []driver.Value{nil},
+		},
+		{
+			name:           "hstore",
+			arg:            HStore{"k": "v"},
+			expectedQuery:  "SELECT * FROM t WHERE a = ?",
+			expectedValues: []driver.Value{`"k"=>"v"`},
+		},
+		{
+			name:           "money",
+			arg:            Money{Cents: -1234},
+			expectedQuery:  "SELECT * FROM t WHERE a = ?",
+			expectedValues: []driver.Value{"-12.34"},
+		},
+		{
+			name:           "enum",
+			arg:            green,
+			expectedQuery:  "SELECT * FROM t WHERE a = ?",
+			expectedValues: []driver.Value{"green"},
+		},
+		{
+			name:           "enum list",
+			arg:            []shade{red, green},
+			expectedQuery:  "SELECT * FROM t WHERE a = (?,?)",
+			expectedValues: []driver.Value{"red", "green"},
+		},
+		{
+			name:    "unknown enum",
+			arg:     blue,
+			wantErr: true,
+		},
+		{
+			name:           "column",
+			arg:            Column("created"),
+			expectedQuery:  `SELECT * FROM t WHERE a = "created"`,
+			expectedValues: []driver.Value{},
+		},
+		{
+			name:           "interval",
+			arg:            Interval{Duration: time.Hour},
+			expectedQuery:  "SELECT * FROM t WHERE a = INTERVAL(0 01:00:00) DAY TO SECOND",
+			expectedValues: []driver.Value{},
+		},
+		{
+			name:           "serial",
+			arg:            Serial{},
+			expectedQuery:  "SELECT * FROM t WHERE a = 0",
+			expectedValues: []driver.Value{},
+		},
+		{
+			name:           "cast",
+			arg:            Cast("1", "INTEGER"),
+			expectedQuery:  "SELECT * FROM t WHERE a = CAST(? AS INTEGER)",
+			expectedValues: []driver.Value{"1"},
+		},
+		{
+			name:           "date",
+			arg:            DateOnly(day),
+			expectedQuery:  "SELECT * FROM t WHERE a = ?",
+			expectedValues: []driver.Value{day},
+		},
+		{
+			name:           "time of day",
+			arg:            TimeOnly(day),
+			expectedQuery:  "SELECT * FROM t WHERE a = ?",
+			expectedValues: []driver.Value{day},
+		},
+		{
+			name:           "row",
+			arg:            Row{Value: struct{ A, B interface{} }{A: 1, B: "a"}},
+			expectedQuery:  "SELECT * FROM t WHERE a = ROW(?,?)",
+			expectedValues: []driver.Value{int64(1), "a"},
+		},
+		{
+			name:           "nil row",
+			arg:            Row{},
+			expectedQuery:  "SELECT * FROM t WHERE a = ?",
+			expectedValues: []driver.Value{nil},
+		},
+		{
+			name:           "tuples",
+			arg:            Tuples{{1, "x"}, {2, "y"}},
+			expectedQuery:  "SELECT * FROM t WHERE a = ((?,?),(?,?))",
+			expectedValues: []driver.Value{int64(1), "x", int64(2), "y"},
+		},
+		{
+			name:    "uneven tuples",
+			arg:     Tuples{{1, 2}, {3}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid cast type",
+			arg:     Cast(1, "INTEGER) OR (1"),
+			wantErr: true,
+		},
+		{
+			name:           "json.RawMessage",
+			arg:            json.RawMessage(`{"a":1}`),
+			expectedQuery:  "SELECT * FROM t WHERE a = ?",
+			expectedValues: []driver.Value{`{"a":1}`},
+		},
+		{
+			name:           "hardware address",
+			arg:            net.HardwareAddr{0, 1, 2, 3, 4, 5},
+			expectedQuery:  "SELECT * FROM t WHERE a = ?",
+			expectedValues: []driver.Value{"00:01:02:03:04:05"},
+		},
+		{
+			name:           "text marshaler struct",
+			arg:            netip.MustParseAddr("10.0.0.1"),
+			expectedQuery:  "SELECT * FROM t WHERE a = ?",
+			expectedValues: []driver.Value{"10.0.0.1"},
+		},
+		{
+			name:           "json struct",
+			arg:            struct{ A int }{A: 1},
+			expectedQuery:  "SELECT * FROM t WHERE a = ?",
+			expectedValues: []driver.Value{`{"A":1}`},
+		},
+		{
+			name:           "json map",
+			arg:            map[string]int{"a": 1},
+			expectedQuery:  "SELECT * FROM t WHERE a = ?",
+			expectedValues: []driver.Value{`{"a":1}`},
+		},
+		{
+			name:           "pointer to slice",
+			arg:            &[]int{1, 2},
+			expectedQuery:  "SELECT * FROM t WHERE a = (?,?)",
+			expectedValues: []driver.Value{int64(1), int64(2)},
+		},
+		{
+			name:           "duration",
+			arg:            5 * time.Nanosecond,
+			expectedQuery:  "SELECT * FROM t WHERE a = ?",
+			expectedValues: []driver.Value{"5ns"},
+		},
+		{
+			name:           "registered formatter",
+			arg:            point{1, 2},
+			expectedQuery:  "SELECT * FROM t WHERE a = ST_Point(1, 2)",
+			expectedValues: []driver.Value{},
+		},
+		{
+			name:           "interface slice",
+			arg:            []interface{}{1, "a"},
+			expectedQuery:  "SELECT * FROM t WHERE a = ARRAY[1,'a']",
+			expectedValues: []driver.Value{},
+		},
+		{
+			name:           "list of columns",
+			arg:            []Column{"a", "b"},
+			expectedQuery:  `SELECT * FROM t WHERE a = ("a","b")`,
+			expectedValues: []driver.Value{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, values, err := Parameterize("SELECT * FROM t WHERE a = $1", tt.arg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parameterize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got != tt.expectedQuery {
+				t.Errorf("Parameterize() query = %v, want %v", got, tt.expectedQuery)
+			}
+			if !reflect.DeepEqual(values, tt.expectedValues) {
+				t.Errorf("Parameterize() values = %#v, want %#v", values, tt.expectedValues)
+			}
+		})
+	}
+}
+
+// TestParameterizeAgreesWithFormat checks, for every type that
+// formatArgument treats specially, that Parameterize binds what
+// InterpolateQuery writes: interpolating the bound values into the
+// parameterized query gives the interpolated query. Exact numbers are
+// bound as text and dates and times of day as a time.Time, so those
+// come back as a string or timestamp, given by bound.
+func TestParameterizeAgreesWithFormat(t *testing.T) {
+	RegisterFormatter(reflect.TypeOf(point{}), func(v interface{}) (string, error) {
+		p := v.(point)
+		return fmt.Sprintf("ST_Point(%g, %g)", p.X, p.Y), nil
+	})
+	defer RegisterFormatter(reflect.TypeOf(point{}), nil)
+	RegisterEnum(map[shade]string{red: "red", green: "green"})
+	defer RegisterEnum[shade, string](nil)
+
+	type flag bool
+	type id int64
+	type count uint16
+	type ratio float32
+	type label string
+	type blob []byte
+
+	day := time.Date(2024, 2, 12, 15, 4, 5, 0, time.UTC)
+	n := 7
+	_, network, _ := net.ParseCIDR("10.0.0.0/8")
+
+	tests := []struct {
+		name  string
+		arg   interface{}
+		bound string
+	}{
+		{name: "nil", arg: nil},
+		{name: "registered formatter", arg: point{1, 2}},
+		{name: "enum", arg: green},
+		{name: "typed nil", arg: (*int)(nil)},
+		{name: "sql.Null", arg: sql.Null[string]{V: "x", Valid: true}},
+		{name: "invalid sql.Null", arg: sql.Null[int]{}},
+		{name: "decimal", arg: fakeDecimal{coef: big.NewInt(1250), exp: -2}, bound: "'12.50'"},
+		{name: "valuer", arg: customValuer{value: "v"}},
+		{name: "bool", arg: true},
+		{name: "int", arg: -3},
+		{name: "uint", arg: uint8(3)},
+		{name: "float", arg: 1.5},
+		{name: "string", arg: "O'Connor"},
+		{name: "char", arg: Char('A')},
+		{name: "like", arg: LikeValue("50%")},
+		{name: "hstore", arg: HStore{"k": "v"}},
+		{name: "ip", arg: net.ParseIP("10.0.0.1")},
+		{name: "ip network", arg: *network},
+		{name: "ip network pointer", arg: network},
+		{name: "bytes", arg: []byte{1, 2}},
+		{name: "text", arg: Text("abc")},
+		{name: "raw bytes", arg: sql.RawBytes("abc")},
+		{name: "runes", arg: Runes("abc")},
+		{name: "time", arg: day},
+		{name: "time pointer", arg: &day},
+		{name: "date", arg: DateOnly(day), bound: "'2024-02-12 15:04:05'"},
+		{name: "time of day", arg: TimeOnly(day), bound: "'2024-02-12 15:04:05'"},
+		{name: "interval", arg: Interval{Duration: time.Hour}},
+		{name: "column", arg: Column("created")},
+		{name: "raw", arg: Raw("CURRENT")},
+		{name: "serial", arg: Serial{}},
+		{name: "money", arg: Money{Cents: 1234}, bound: "'12.34'"},
+		{name: "rat", arg: big.NewRat(1, 4), bound: "'0.25'"},
+		{name: "column interval", arg: ColumnInterval{Column: "d", Interval: Interval{Duration: time.Hour}}},
+		{name: "row", arg: Row{Value: struct{ A, B interface{} }{A: 1, B: "a"}}},
+		{name: "tuples", arg: Tuples{{1, "x"}, {2, "y"}}},
+		{name: "cast", arg: Cast("1", "INTEGER")},
+		{name: "inline", arg: Inline{Value: 3}},
+		{name: "interface slice", arg: []interface{}{1, "a"}},
+		{name: "json.RawMessage", arg: json.RawMessage(`{"a":1}`)},
+		{name: "json map", arg: map[string]interface{}{"a": 1}},
+		{name: "json.Number", arg: json.Number("12.5"), bound: "'12.5'"},
+		{name: "uuid", arg: [16]byte{1}},
+		{name: "text marshaler", arg: netip.MustParseAddr("10.0.0.1")},
+		{name: "binary marshaler", arg: packed{a: 1, b: 2}},
+		{name: "stringer", arg: 5 * time.Nanosecond},
+		{name: "hardware address", arg: net.HardwareAddr{0, 1, 2, 3, 4, 5}},
+		{name: "named bool", arg: flag(true)},
+		{name: "named integer", arg: id(9)},
+		{name: "named unsigned", arg: count(9)},
+		{name: "named float", arg: ratio(0.5)},
+		{name: "named string", arg: label("x")},
+		{name: "pointer", arg: &n},
+		{name: "pointer to slice", arg: &[]int{1, 2}},
+		{name: "byte array", arg: [2]byte{1, 2}},
+		{name: "named bytes", arg: blob{1, 2}},
+		{name: "list", arg: []string{"a", "b"}},
+		{name: "nested list", arg: [][]int{{1}, {2}}},
+		{name: "struct", arg: struct{ A int }{A: 1}},
+		{name: "map", arg: map[string]int{"a": 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := formatArgument(tt.arg)
+			if err != nil {
+				t.Fatalf("formatArgument() error = %v", err)
+			}
+			if tt.bound != "" {
+				want = tt.bound
+			}
+			query, values, err := Parameterize("$1", tt.arg)
+			if err != nil {
+				t.Fatalf("Parameterize() error = %v", err)
+			}
+			args := make([]interface{}, len(values))
+			for i, v := range values {
+				args[i] = v
+			}
+			got, err := InterpolateQuery(query, args...)
+			if err != nil {
+				t.Fatalf("InterpolateQuery(%q) error = %v", query, err)
+			}
+			if got != want {
+				t.Errorf("Parameterize() = %q, %#v, which interpolates to %v, want %v", query, values, got, want)
+			}
+		})
+	}
+}
+
+func TestParameterizeMatchesInterpolation(t *testing.T) {
+	query := "SELECT * FROM t WHERE id IN $1 AND tag IN $2 AND name = $3"
+
+	tests := []struct {
+		name                string
+		ip                  *Interpolator
+		args                []interface{}
+		expectedInterpolate string
+		expectedParameters  string
+	}{
+		{
+			name:                "lists",
+			ip:                  &Interpolator{},
+			args:                []interface{}{[]int{1, 2, 3}, []string{"a"}, "x"},
+			expectedInterpolate: "SELECT * FROM t WHERE id IN (1,2,3) AND tag IN ('a') AND name = 'x'",
+			expectedParameters:  "SELECT * FROM t WHERE id IN (?,?,?) AND tag IN (?) AND name = ?",
+		},
+		{
+			name:                "empty list as null",
+			ip:                  &Interpolator{EmptySlice: EmptyNull},
+			args:                []interface{}{[]int{}, []string{"a"}, "x"},
+			expectedInterpolate: "SELECT * FROM t WHERE id IN (NULL) AND tag IN ('a') AND name = 'x'",
+			expectedParameters:  "SELECT * FROM t WHERE id IN (NULL) AND tag IN (?) AND name = ?",
+		},
+		{
+			name:                "collections",
+			ip:                  &Interpolator{Collections: true},
+			args:                []interface{}{[]int{1, 2}, []string{"a"}, "x"},
+			expectedInterpolate: "SELECT * FROM t WHERE id IN ARRAY[1,2] AND tag IN ARRAY['a'] AND name = 'x'",
+			expectedParameters:  "SELECT * FROM t WHERE id IN ARRAY[1,2] AND tag IN ARRAY['a'] AND name = ?",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.ip.InterpolateQuery(query, tt.args...)
+			if err != nil {
+				t.Fatalf("InterpolateQuery() error = %v", err)
+			}
+			if got != tt.expectedInterpolate {
+				t.Errorf("InterpolateQuery() = %v, want %v", got, tt.expectedInterpolate)
+			}
+			got, _, err = tt.ip.Parameterize(query, tt.args...)
+			if err != nil {
+				t.Fatalf("Parameterize() error = %v", err)
+			}
+			if got != tt.expectedParameters {
+				t.Errorf("Parameterize() = %v, want %v", got, tt.expectedParameters)
+			}
+		})
+	}
+}
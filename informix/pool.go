@@ -0,0 +1,36 @@
+package informix
+
+import (
+	"bytes"
+	"sync"
+)
+
+// maxPooledBuffer is the capacity above which a buffer is dropped
+// rather than returned to bufferPool, so that one huge query does not
+// pin its memory for good.
+const maxPooledBuffer = 64 << 10
+
+// bufferPool holds the buffers that queries are built in. It holds
+// bytes.Buffers rather than strings.Builders: the string returned by a
+// strings.Builder shares its memory, so the builder cannot be reused
+// afterwards, whereas bytes.Buffer.String copies. Lists and
+// collections are built in a strings.Builder instead, since the copy
+// would cost more than pooling saves for a value that is written into
+// the query and dropped.
+var bufferPool = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
+// getBuffer returns an empty buffer from bufferPool.
+func getBuffer() *bytes.Buffer {
+	return bufferPool.Get().(*bytes.Buffer)
+}
+
+// putBuffer returns b to bufferPool. b must not be used afterwards.
+func putBuffer(b *bytes.Buffer) {
+	if b.Cap() > maxPooledBuffer {
+		return
+	}
+	b.Reset()
+	bufferPool.Put(b)
+}
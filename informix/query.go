@@ -0,0 +1,85 @@
+package informix
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Query is a fmt.Sprintf for SQL. It returns format with each verb
+// replaced by the next argument:
+//
+//	%s  the argument as a SQL literal, as InterpolateQuery renders it
+//	%i  the argument, a string or Column, quoted with QuoteIdentifier
+//	%r  the argument, a string or Raw, written verbatim
+//	%%  a percent sign
+//
+// Any other verb is an error, so a literal percent sign in format, as in
+// LIKE 'a%', must be written as %%. The number of arguments must match
+// the number of verbs. %r must never be given user input.
+func Query(format string, args ...interface{}) (string, error) {
+	return defaultInterpolator().Query(format, args...)
+}
+
+// Query is like the package level Query, but renders values using the
+// settings of ip.
+func (ip *Interpolator) Query(format string, args ...interface{}) (string, error) {
+	var b strings.Builder
+	b.Grow(len(format))
+	next := 0
+	for i := 0; i < len(format); i++ {
+		c := format[i]
+		if c != '%' {
+			b.WriteByte(c)
+			continue
+		}
+		if i+1 >= len(format) {
+			return "", fmt.Errorf("format ends with %%")
+		}
+		i++
+		verb := format[i]
+		if verb == '%' {
+			b.WriteByte('%')
+			continue
+		}
+		if next >= len(args) {
+			return "", fmt.Errorf("%w: %%%c at offset %d has no argument", ErrTooFewArgs, verb, i-1)
+		}
+		arg := args[next]
+		next++
+
+		var s string
+		var err error
+		switch verb {
+		case 's':
+			s, err = ip.formatArgument(arg)
+		case 'i':
+			switch v := arg.(type) {
+			case string:
+				s, err = QuoteIdentifier(v)
+			case Column:
+				s, err = QuoteIdentifier(string(v))
+			default:
+				err = fmt.Errorf("%%i needs a string or Column, got %T", arg)
+			}
+		case 'r':
+			switch v := arg.(type) {
+			case string:
+				s = v
+			case Raw:
+				s = string(v)
+			default:
+				err = fmt.Errorf("%%r needs a string or Raw, got %T", arg)
+			}
+		default:
+			err = fmt.Errorf("unknown verb %%%c", verb)
+		}
+		if err != nil {
+			return "", fmt.Errorf("argument %d: %w", next, err)
+		}
+		b.WriteString(s)
+	}
+	if next < len(args) {
+		return "", fmt.Errorf("%w: %d verbs, got %d arguments", ErrTooManyArgs, next, len(args))
+	}
+	return b.String(), nil
+}
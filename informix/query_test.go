@@ -0,0 +1,94 @@
+package informix
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		args     []interface{}
+		expected string
+		wantErr  error
+	}{
+		{
+			name:     "values",
+			format:   "SELECT * FROM t WHERE a = %s AND b = %s",
+			args:     []interface{}{"it's", 42},
+			expected: "SELECT * FROM t WHERE a = 'it''s' AND b = 42",
+		},
+		{
+			name:     "identifiers",
+			format:   "SELECT %i FROM %i",
+			args:     []interface{}{`we"ird`, Column("t")},
+			expected: `SELECT "we""ird" FROM "t"`,
+		},
+		{
+			name:     "raw",
+			format:   "SELECT * FROM t ORDER BY %r LIMIT %s",
+			args:     []interface{}{"created DESC", 10},
+			expected: "SELECT * FROM t ORDER BY created DESC LIMIT 10",
+		},
+		{
+			name:     "percent",
+			format:   "SELECT * FROM t WHERE a LIKE 'x%%' AND b = %s",
+			args:     []interface{}{"100%"},
+			expected: "SELECT * FROM t WHERE a LIKE 'x%' AND b = '100%'",
+		},
+		{
+			name:    "too few arguments",
+			format:  "SELECT %s, %s",
+			args:    []interface{}{1},
+			wantErr: ErrTooFewArgs,
+		},
+		{
+			name:    "too many arguments",
+			format:  "SELECT %s",
+			args:    []interface{}{1, 2},
+			wantErr: ErrTooManyArgs,
+		},
+		{
+			name:    "empty identifier",
+			format:  "SELECT %i",
+			args:    []interface{}{""},
+			wantErr: errAny,
+		},
+		{
+			name:    "identifier of wrong type",
+			format:  "SELECT %i",
+			args:    []interface{}{1},
+			wantErr: errAny,
+		},
+		{
+			name:    "unknown verb",
+			format:  "SELECT %d",
+			args:    []interface{}{1},
+			wantErr: errAny,
+		},
+		{
+			name:    "trailing percent",
+			format:  "SELECT 1 %",
+			wantErr: errAny,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Query(tt.format, tt.args...)
+			switch {
+			case tt.wantErr == errAny && err == nil:
+				t.Fatalf("Query() error = nil, want an error")
+			case tt.wantErr != errAny && !errors.Is(err, tt.wantErr):
+				t.Fatalf("Query() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("Query() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// errAny stands for any error in test tables.
+var errAny = errors.New("any error")
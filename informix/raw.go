@@ -0,0 +1,16 @@
+package informix
+
+// Raw is SQL text that is written into a query verbatim, without quoting
+// or escaping. It lets a caller pass an expression such as CURRENT or
+// TODAY where a value would otherwise go.
+//
+// Raw bypasses all of the protection this package gives: never convert
+// input that may come from a user to Raw, as it opens the query to SQL
+// injection.
+type Raw string
+
+// Informix expressions for the current time, evaluated by the server.
+const (
+	Current Raw = "CURRENT"
+	Today   Raw = "TODAY"
+)
@@ -0,0 +1,97 @@
+package informix
+
+import "testing"
+
+func TestRaw(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		args     []interface{}
+		expected string
+	}{
+		{
+			name:     "current",
+			query:    "UPDATE t SET modified = ?",
+			args:     []interface{}{Current},
+			expected: "UPDATE t SET modified = CURRENT",
+		},
+		{
+			name:     "today",
+			query:    "SELECT * FROM t WHERE due < ?",
+			args:     []interface{}{Today},
+			expected: "SELECT * FROM t WHERE due < TODAY",
+		},
+		{
+			name:     "raw function call",
+			query:    "INSERT INTO t VALUES (?)",
+			args:     []interface{}{Raw("CURRENT")},
+			expected: "INSERT INTO t VALUES (CURRENT)",
+		},
+		{
+			name:     "plain string is quoted",
+			query:    "INSERT INTO t VALUES (?)",
+			args:     []interface{}{"CURRENT"},
+			expected: "INSERT INTO t VALUES ('CURRENT')",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := InterpolateQuery(tt.query, tt.args...)
+			if err != nil {
+				t.Fatalf("InterpolateQuery() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("InterpolateQuery() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRawMixedArgs(t *testing.T) {
+	type label string
+
+	tests := []struct {
+		name     string
+		query    string
+		args     []interface{}
+		expected string
+	}{
+		{
+			name:     "raw between quoted values",
+			query:    "SELECT * FROM t WHERE a = ? ORDER BY ? LIMIT ?",
+			args:     []interface{}{"it's", Raw("created DESC"), 10},
+			expected: "SELECT * FROM t WHERE a = 'it''s' ORDER BY created DESC LIMIT 10",
+		},
+		{
+			name:     "raw is not escaped",
+			query:    "SELECT ?, ?",
+			args:     []interface{}{Raw("'x'"), "'x'"},
+			expected: "SELECT 'x', '''x'''",
+		},
+		{
+			name:     "other string types are quoted",
+			query:    "SELECT ?",
+			args:     []interface{}{label("CURRENT")},
+			expected: "SELECT 'CURRENT'",
+		},
+		{
+			name:     "dollar placeholders",
+			query:    "SELECT $2 FROM t WHERE id = $1",
+			args:     []interface{}{7, Raw("COUNT(*)")},
+			expected: "SELECT COUNT(*) FROM t WHERE id = 7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := InterpolateQuery(tt.query, tt.args...)
+			if err != nil {
+				t.Fatalf("InterpolateQuery() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("InterpolateQuery() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
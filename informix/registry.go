@@ -0,0 +1,93 @@
+package informix
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// formatters holds the formatters added with RegisterFormatter.
+var formatters struct {
+	sync.RWMutex
+	m map[reflect.Type]func(interface{}) (string, error)
+}
+
+// RegisterFormatter makes fn render arguments of type t. fn returns
+// the complete SQL text for the value, so it is responsible for any
+// quoting and escaping. Registered formatters take precedence over the
+// built in handling of a type, including driver.Valuer.
+//
+// RegisterFormatter is safe to call concurrently with interpolation,
+// but formatters are meant to be registered from init functions.
+// Registering a nil fn removes the formatter for t.
+func RegisterFormatter(t reflect.Type, fn func(interface{}) (string, error)) {
+	formatters.Lock()
+	defer formatters.Unlock()
+	if fn == nil {
+		delete(formatters.m, t)
+		return
+	}
+	if formatters.m == nil {
+		formatters.m = make(map[reflect.Type]func(interface{}) (string, error))
+	}
+	formatters.m[t] = fn
+}
+
+// lookupFormatter returns the formatter registered for t, if any.
+func lookupFormatter(t reflect.Type) func(interface{}) (string, error) {
+	formatters.RLock()
+	defer formatters.RUnlock()
+	return formatters.m[t]
+}
+
+// ErrUnknownEnum is returned for a value of a type registered with
+// RegisterEnum that has no mapping.
+var ErrUnknownEnum = errors.New("enum value has no registered mapping")
+
+// enums holds the mappings added with RegisterEnum.
+var enums struct {
+	sync.RWMutex
+	m map[reflect.Type]func(interface{}) (interface{}, error)
+}
+
+// RegisterEnum makes values of type T render as the value mapped to
+// them, such as an integer enum as its name. The mapped value is
+// formatted like any other argument, so Color(2) mapped to "green" is
+// written as 'green'. A value of T missing from values is an error
+// wrapping ErrUnknownEnum, rather than being written as it is, so that
+// a new enum constant cannot silently reach the database unmapped.
+//
+// values is copied. Like RegisterFormatter, RegisterEnum is safe to
+// call concurrently with interpolation, and a formatter registered for
+// T takes precedence. Registering a nil map removes the mapping for T.
+func RegisterEnum[T comparable, V any](values map[T]V) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	enums.Lock()
+	defer enums.Unlock()
+	if values == nil {
+		delete(enums.m, t)
+		return
+	}
+	m := make(map[T]V, len(values))
+	for k, v := range values {
+		m[k] = v
+	}
+	if enums.m == nil {
+		enums.m = make(map[reflect.Type]func(interface{}) (interface{}, error))
+	}
+	enums.m[t] = func(arg interface{}) (interface{}, error) {
+		v, ok := m[arg.(T)]
+		if !ok {
+			return nil, fmt.Errorf("%w: %T(%v)", ErrUnknownEnum, arg, arg)
+		}
+		return v, nil
+	}
+}
+
+// lookupEnum returns the enum mapping registered for t, if any.
+func lookupEnum(t reflect.Type) func(interface{}) (interface{}, error) {
+	enums.RLock()
+	defer enums.RUnlock()
+	return enums.m[t]
+}
@@ -0,0 +1,179 @@
+package informix
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+type point struct {
+	X, Y float64
+}
+
+type celsius float64
+
+func TestRegisterFormatter(t *testing.T) {
+	errNaN := errors.New("temperature is not a number")
+
+	RegisterFormatter(reflect.TypeOf(point{}), func(v interface{}) (string, error) {
+		p := v.(point)
+		return fmt.Sprintf("ST_Point(%g, %g)", p.X, p.Y), nil
+	})
+	defer RegisterFormatter(reflect.TypeOf(point{}), nil)
+	RegisterFormatter(reflect.TypeOf(celsius(0)), func(v interface{}) (string, error) {
+		c := v.(celsius)
+		if c != c {
+			return "", errNaN
+		}
+		return fmt.Sprintf("%.1f", float64(c)), nil
+	})
+	defer RegisterFormatter(reflect.TypeOf(celsius(0)), nil)
+
+	zero := 0.0
+	tests := []struct {
+		name     string
+		arg      interface{}
+		expected string
+		wantErr  error
+	}{
+		{
+			name:     "registered struct",
+			arg:      point{X: 1.5, Y: -2},
+			expected: "ST_Point(1.5, -2)",
+		},
+		{
+			name:     "registered scalar",
+			arg:      celsius(21.25),
+			expected: "21.2",
+		},
+		{
+			name:    "formatter error",
+			arg:     celsius(zero / zero),
+			wantErr: errNaN,
+		},
+		{
+			name:     "unregistered type",
+			arg:      3.5,
+			expected: "3.500000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := InterpolateQuery("SELECT $1", tt.arg)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("InterpolateQuery() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if expected := "SELECT " + tt.expected; got != expected {
+				t.Errorf("InterpolateQuery() = %v, want %v", got, expected)
+			}
+		})
+	}
+
+	RegisterFormatter(reflect.TypeOf(celsius(0)), nil)
+	if got, _ := formatArgument(celsius(1)); got != "1.000000" {
+		t.Errorf("formatArgument() after unregister = %v, want %v", got, "1.000000")
+	}
+}
+
+type shade int
+
+const (
+	red shade = iota + 1
+	green
+	blue
+)
+
+type grade string
+
+func TestRegisterEnum(t *testing.T) {
+	RegisterEnum(map[shade]string{red: "red", green: "green", blue: "blue"})
+	defer RegisterEnum[shade, string](nil)
+	RegisterEnum(map[grade]int{"low": 1, "high": 3})
+	defer RegisterEnum[grade, int](nil)
+
+	tests := []struct {
+		name     string
+		dialect  Dialect
+		arg      interface{}
+		expected string
+		wantErr  error
+	}{
+		{
+			name:     "known value",
+			arg:      green,
+			expected: "'green'",
+		},
+		{
+			name:     "pointer",
+			arg:      func() *shade { s := blue; return &s }(),
+			expected: "'blue'",
+		},
+		{
+			name:     "list",
+			arg:      []shade{red, blue},
+			expected: "('red','blue')",
+		},
+		{
+			name:     "string to int",
+			arg:      grade("high"),
+			expected: "3",
+		},
+		{
+			name:    "unknown value",
+			arg:     shade(7),
+			wantErr: ErrUnknownEnum,
+		},
+		{
+			name:    "unknown value in list",
+			arg:     []shade{red, 0},
+			wantErr: ErrUnknownEnum,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip := &Interpolator{Dialect: tt.dialect}
+			got, err := ip.InterpolateQuery("SELECT $1", tt.arg)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("InterpolateQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if expected := "SELECT " + tt.expected; got != expected {
+				t.Errorf("InterpolateQuery() = %v, want %v", got, expected)
+			}
+		})
+	}
+
+	RegisterEnum[shade, string](nil)
+	if got, _ := formatArgument(green); got != "2" {
+		t.Errorf("formatArgument() after unregister = %v, want %v", got, "2")
+	}
+}
+
+func TestRegisterEnumConcurrent(t *testing.T) {
+	defer RegisterEnum[grade, int](nil)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			RegisterEnum(map[grade]int{"low": i})
+		}(i)
+		go func() {
+			defer wg.Done()
+			if _, err := formatArgument(grade("low")); err != nil {
+				t.Errorf("formatArgument() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
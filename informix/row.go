@@ -0,0 +1,72 @@
+package informix
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Row formats a struct or a map with string keys as an Informix ROW
+// literal, such as ROW(1,'Smith',NULL). Struct fields are written in
+// declaration order and unexported fields are skipped; map entries are
+// written in key order. Each field is formatted like any other argument.
+type Row struct {
+	Value interface{}
+}
+
+// formatRow returns r as a ROW literal.
+func (ip *Interpolator) formatRow(r Row) (string, error) {
+	fields, ok, err := r.fields()
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "NULL", nil
+	}
+
+	values := make([]string, len(fields))
+	for i, f := range fields {
+		s, err := ip.formatArgument(f.Interface())
+		if err != nil {
+			return "", err
+		}
+		values[i] = s
+	}
+	return fmt.Sprintf("ROW(%s)", strings.Join(values, ",")), nil
+}
+
+// fields returns the fields of r in the order they are written. It
+// returns ok false if r is NULL, as for a nil value or nil pointer.
+func (r Row) fields() (fields []reflect.Value, ok bool, err error) {
+	rv := reflect.ValueOf(r.Value)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, false, nil
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return nil, false, nil
+	case reflect.Struct:
+		for i := 0; i < rv.NumField(); i++ {
+			if rv.Type().Field(i).IsExported() {
+				fields = append(fields, rv.Field(i))
+			}
+		}
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, false, fmt.Errorf("%w: row from %s", ErrUnsupportedType, rv.Type())
+		}
+		keys := rv.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		for _, k := range keys {
+			fields = append(fields, rv.MapIndex(k))
+		}
+	default:
+		return nil, false, fmt.Errorf("%w: row from %s", ErrUnsupportedType, rv.Type())
+	}
+	return fields, true, nil
+}
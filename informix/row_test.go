@@ -0,0 +1,88 @@
+package informix
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRow(t *testing.T) {
+	type address struct {
+		Street string
+		Number int
+	}
+	type person struct {
+		ID       int
+		Name     string
+		Born     time.Time
+		Active   bool
+		Nickname *string
+		Address  Row
+		internal string
+	}
+
+	tests := []struct {
+		name     string
+		input    Row
+		expected string
+		wantErr  bool
+	}{
+		{
+			name: "struct with mixed fields",
+			input: Row{Value: person{
+				ID:      1,
+				Name:    "O'Brien",
+				Born:    time.Date(1990, 5, 1, 0, 0, 0, 0, time.UTC),
+				Active:  true,
+				Address: Row{Value: address{Street: "Main", Number: 7}},
+			}},
+			expected: "ROW(1,'O''Brien','1990-05-01 00:00:00',true,NULL,ROW('Main',7))",
+		},
+		{
+			name:     "nil fields",
+			input:    Row{Value: struct{ A, B interface{} }{A: nil, B: 2}},
+			expected: "ROW(NULL,2)",
+		},
+		{
+			name:     "pointer to struct",
+			input:    Row{Value: &address{Street: "Elm", Number: 3}},
+			expected: "ROW('Elm',3)",
+		},
+		{
+			name:     "map in key order",
+			input:    Row{Value: map[string]interface{}{"b": "x", "a": 1, "c": nil}},
+			expected: "ROW(1,'x',NULL)",
+		},
+		{
+			name:     "nil value",
+			input:    Row{},
+			expected: "NULL",
+		},
+		{
+			name:     "nil pointer",
+			input:    Row{Value: (*address)(nil)},
+			expected: "NULL",
+		},
+		{
+			name:    "map with non-string keys",
+			input:   Row{Value: map[int]string{1: "a"}},
+			wantErr: true,
+		},
+		{
+			name:    "scalar",
+			input:   Row{Value: 5},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatArgument(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("formatArgument() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("formatArgument() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,466 @@
+package informix
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnterminated is returned for a query that ends inside a string
+// literal, a dollar-quoted string, a quoted identifier or a block
+// comment.
+var ErrUnterminated = errors.New("unterminated quote or comment")
+
+// QueryError reports where in a query a problem was found. It wraps the
+// error describing the problem, such as ErrUnterminated or
+// ErrTooFewArgs, for errors.Is.
+type QueryError struct {
+	// Offset is the byte offset of the offending token in the query.
+	Offset int
+
+	// Line and Column locate Offset for people, counting from 1.
+	// Column counts bytes.
+	Line, Column int
+
+	Err error
+}
+
+// newQueryError returns a QueryError for err at query[offset].
+func newQueryError(query string, offset int, err error) *QueryError {
+	line := 1 + strings.Count(query[:offset], "\n")
+	column := offset - strings.LastIndexByte(query[:offset], '\n')
+	return &QueryError{Offset: offset, Line: line, Column: column, Err: err}
+}
+
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("line %d, column %d (offset %d): %v", e.Line, e.Column, e.Offset, e.Err)
+}
+
+func (e *QueryError) Unwrap() error {
+	return e.Err
+}
+
+// placeholderKind is the style of a placeholder token.
+type placeholderKind int
+
+const (
+	questionMark placeholderKind = iota // ?
+	dollarNumber                        // $1, $2, ..., or :1, :2, ... in StyleColonNumeric
+	namedParam                          // :name or @name
+)
+
+// placeholder is a placeholder token found in a query.
+type placeholder struct {
+	// start and end are the byte offsets of the token in the query.
+	start, end int
+
+	kind placeholderKind
+
+	// num is n for a $n placeholder.
+	num int
+
+	// name is the name of a named placeholder, without its prefix.
+	name string
+
+	// index is the index of the argument the placeholder refers to,
+	// as set by assignIndexes.
+	index int
+}
+
+// scanPlaceholders returns the positional placeholders of query in
+// order, in the syntax selected by ip.Style. Tokens inside string
+// literals, dollar-quoted strings, quoted identifiers, -- line comments
+// and /* */ block comments are not placeholders. An error wrapping
+// ErrUnterminated is returned if a literal, quoted identifier or block
+// comment is not closed. It also rejects settings of ip that no
+// positional query can use.
+func (ip *Interpolator) scanPlaceholders(query string) ([]placeholder, error) {
+	if ip.Style == StyleNamed {
+		return nil, errors.New("StyleNamed queries take named arguments")
+	}
+	if ip.PlaceholderBase < 0 && ip.PlaceholderBase != ZeroBase {
+		return nil, fmt.Errorf("PlaceholderBase %d is negative; use ZeroBase to number placeholders from $0", ip.PlaceholderBase)
+	}
+	return scan(query, ip.Style, 0)
+}
+
+// scanNamedPlaceholders returns the named placeholders of query in
+// order. A named placeholder is prefix followed by an identifier.
+// Doubled prefixes, as in a ::type cast or an @@variable, and prefixes
+// that follow an identifier character, as in user@example, do not
+// start a placeholder.
+func scanNamedPlaceholders(query string, prefix byte) ([]placeholder, error) {
+	return scan(query, StyleNamed, prefix)
+}
+
+// scan returns the placeholders of query in the given style. Named
+// placeholders start with namePrefix. Results are cached when the
+// query cache is enabled.
+func scan(query string, style Style, namePrefix byte) ([]placeholder, error) {
+	if !queryCache.enabled() {
+		return scanQuery(query, style, namePrefix)
+	}
+	key := scanKey{query: query, style: style, namePrefix: namePrefix}
+	if phs, ok := queryCache.get(key); ok {
+		return phs, nil
+	}
+	phs, err := scanQuery(query, style, namePrefix)
+	if err != nil {
+		return nil, err
+	}
+	queryCache.add(key, phs)
+	return phs, nil
+}
+
+// scanQuery implements scan.
+func scanQuery(query string, style Style, namePrefix byte) ([]placeholder, error) {
+	var phs []placeholder
+	for i := 0; i < len(query); {
+		j, err := skipNonCode(query, i)
+		if err != nil {
+			return nil, err
+		}
+		if j > i {
+			i = j
+			continue
+		}
+		switch c := query[i]; {
+		case style == StyleNamed:
+			if c != namePrefix {
+				i++
+				break
+			}
+			if i+1 < len(query) && query[i+1] == namePrefix {
+				i += 2
+				break
+			}
+			if (i > 0 && isIdentChar(query[i-1])) || i+1 >= len(query) || !isIdentStart(query[i+1]) {
+				i++
+				break
+			}
+			j := i + 1
+			for j < len(query) && isIdentChar(query[j]) {
+				j++
+			}
+			phs = append(phs, placeholder{start: i, end: j, kind: namedParam, name: query[i+1 : j]})
+			i = j
+		case c == '?' && (style == StyleAuto || style == StyleQuestion):
+			phs = append(phs, placeholder{start: i, end: i + 1, kind: questionMark})
+			i++
+		case c == ':' && style == StyleColonNumeric:
+			if i+1 < len(query) && query[i+1] == ':' {
+				i += 2
+				break
+			}
+			if (i > 0 && isIdentChar(query[i-1])) || i+1 >= len(query) || !isDigit(query[i+1]) {
+				i++
+				break
+			}
+			num, j := scanNumber(query, i+1)
+			if j < len(query) && isIdentChar(query[j]) {
+				i = j
+				break
+			}
+			if num < 0 {
+				return nil, newQueryError(query, i, errPlaceholderNumber(query[i:j]))
+			}
+			phs = append(phs, placeholder{start: i, end: j, kind: dollarNumber, num: num})
+			i = j
+		case c == '$' && (style == StyleAuto || style == StyleDollar) && i+1 < len(query) && isDigit(query[i+1]):
+			num, j := scanNumber(query, i+1)
+			if j < len(query) && (isIdentChar(query[j]) || query[j] == '$') {
+				// Not a placeholder but part of a longer token, such
+				// as $1abc, which Postgres rejects too.
+				for j < len(query) && (isIdentChar(query[j]) || query[j] == '$') {
+					j++
+				}
+				i = j
+				break
+			}
+			if num < 0 {
+				return nil, newQueryError(query, i, errPlaceholderNumber(query[i:j]))
+			}
+			phs = append(phs, placeholder{start: i, end: j, kind: dollarNumber, num: num})
+			i = j
+		default:
+			i++
+		}
+	}
+	return phs, nil
+}
+
+// maxPlaceholderNumber is the largest n of a $n or :n placeholder, the
+// most parameters a Postgres statement can have.
+const maxPlaceholderNumber = 65535
+
+// scanNumber returns the value of the digits of query starting at i,
+// and the offset just past them. The value is -1 if it is larger than
+// maxPlaceholderNumber.
+func scanNumber(query string, i int) (num, end int) {
+	for end = i; end < len(query) && isDigit(query[end]); end++ {
+		if num >= 0 {
+			num = num*10 + int(query[end]-'0')
+			if num > maxPlaceholderNumber {
+				num = -1
+			}
+		}
+	}
+	return num, end
+}
+
+// errPlaceholderNumber returns the error for the placeholder token whose
+// number is larger than maxPlaceholderNumber.
+func errPlaceholderNumber(token string) error {
+	return &PlaceholderError{Reason: fmt.Sprintf("placeholder %s: number larger than %d", token, maxPlaceholderNumber)}
+}
+
+// splitStatements splits query into statements at the semicolons that
+// are not inside literals or comments. Every statement keeps its
+// terminating semicolon.
+func splitStatements(query string) ([]string, error) {
+	var stmts []string
+	start := 0
+	for i := 0; i < len(query); {
+		j, err := skipNonCode(query, i)
+		if err != nil {
+			return nil, err
+		}
+		if j > i {
+			i = j
+			continue
+		}
+		if query[i] == ';' {
+			stmts = append(stmts, query[start:i+1])
+			start = i + 1
+		}
+		i++
+	}
+	if start < len(query) {
+		stmts = append(stmts, query[start:])
+	}
+	return stmts, nil
+}
+
+// skipNonCode returns the offset just past the string literal, quoted
+// identifier or comment that starts at query[i], or i if none does.
+// If it is not closed, the error is a *QueryError wrapping
+// ErrUnterminated.
+func skipNonCode(query string, i int) (int, error) {
+	switch c := query[i]; {
+	case c == '\'':
+		if j := skipQuoted(query, i, c); j >= 0 {
+			return j, nil
+		}
+		return 0, newQueryError(query, i, fmt.Errorf("%w: string literal", ErrUnterminated))
+	case c == '"':
+		if j := skipQuoted(query, i, c); j >= 0 {
+			return j, nil
+		}
+		return 0, newQueryError(query, i, fmt.Errorf("%w: quoted identifier", ErrUnterminated))
+	case c == '$' && (i == 0 || !isIdentChar(query[i-1]) && query[i-1] != '$'):
+		tag := dollarTag(query, i)
+		if tag == "" {
+			return i, nil
+		}
+		if n := strings.Index(query[i+len(tag):], tag); n >= 0 {
+			return i + len(tag) + n + len(tag), nil
+		}
+		return 0, newQueryError(query, i, fmt.Errorf("%w: dollar-quoted string", ErrUnterminated))
+	case c == '-' && strings.HasPrefix(query[i:], "--"):
+		return skipLineComment(query, i), nil
+	case c == '/' && strings.HasPrefix(query[i:], "/*"):
+		if j := skipBlockComment(query, i); j >= 0 {
+			return j, nil
+		}
+		return 0, newQueryError(query, i, fmt.Errorf("%w: block comment", ErrUnterminated))
+	}
+	return i, nil
+}
+
+// dollarTag returns the opening delimiter of the dollar-quoted string
+// that starts at query[i], such as $$ or $body$, or "" if there is none.
+// A tag cannot start with a digit, so $1 is left as a placeholder.
+func dollarTag(query string, i int) string {
+	j := i + 1
+	if j < len(query) && isIdentStart(query[j]) {
+		for j < len(query) && isIdentChar(query[j]) {
+			j++
+		}
+	}
+	if j < len(query) && query[j] == '$' {
+		return query[i : j+1]
+	}
+	return ""
+}
+
+// skipQuoted returns the offset just past the literal or quoted
+// identifier that starts at query[i] with quote q, or -1 if it is not
+// closed. A doubled quote does not end it.
+func skipQuoted(query string, i int, q byte) int {
+	for i++; i < len(query); i++ {
+		if query[i] != q {
+			continue
+		}
+		if i+1 < len(query) && query[i+1] == q {
+			i++
+			continue
+		}
+		return i + 1
+	}
+	return -1
+}
+
+// skipLineComment returns the offset of the end of the line comment
+// that starts at query[i].
+func skipLineComment(query string, i int) int {
+	if n := strings.IndexByte(query[i:], '\n'); n >= 0 {
+		return i + n
+	}
+	return len(query)
+}
+
+// skipBlockComment returns the offset just past the block comment that
+// starts at query[i], or -1 if it is not closed.
+func skipBlockComment(query string, i int) int {
+	if n := strings.Index(query[i+2:], "*/"); n >= 0 {
+		return i + 2 + n + 2
+	}
+	return -1
+}
+
+// assignIndexes sets the argument index of every placeholder in phs
+// and returns the number of arguments the query expects. $n refers to
+// argument n-base, so it may be repeated; each ? takes the argument
+// after the one taken by the previous ?.
+func assignIndexes(phs []placeholder, base int) int {
+	next, expected := 0, 0
+	for i := range phs {
+		ph := &phs[i]
+		switch ph.kind {
+		case questionMark:
+			ph.index = next
+			next++
+		case dollarNumber:
+			ph.index = ph.num - base
+		}
+		if ph.index+1 > expected {
+			expected = ph.index + 1
+		}
+	}
+	return expected
+}
+
+// joins reports whether the bytes a and b, written next to each other,
+// would be read as part of one token.
+func joins(a, b byte) bool {
+	switch {
+	case a == '-' && b == '-', a == '/' && b == '*', a == '*' && b == '/':
+		return true
+	case a == '.' && isDigit(b), isDigit(a) && b == '.':
+		return true
+	case a == '$' && isIdentChar(b):
+		return true
+	}
+	word := func(c byte) bool { return isIdentChar(c) || c == '\'' || c == '"' }
+	return word(a) && word(b)
+}
+
+// unusedArgument returns the index of the first of n arguments that no
+// placeholder in phs refers to, or -1 if every argument is used. A
+// placeholder that is repeated counts once.
+func unusedArgument(phs []placeholder, n int) int {
+	referenced := make([]bool, n)
+	for _, ph := range phs {
+		if ph.index >= 0 && ph.index < n {
+			referenced[ph.index] = true
+		}
+	}
+	for i, ok := range referenced {
+		if !ok {
+			return i
+		}
+	}
+	return -1
+}
+
+// usedArguments returns the sorted indices, below n, of the arguments
+// that phs refer to.
+func usedArguments(phs []placeholder, n int) []int {
+	referenced := make([]bool, n)
+	for _, ph := range phs {
+		if ph.index >= 0 && ph.index < n {
+			referenced[ph.index] = true
+		}
+	}
+	var used []int
+	for i, ok := range referenced {
+		if ok {
+			used = append(used, i)
+		}
+	}
+	return used
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || isDigit(c)
+}
+
+// replacePlaceholders returns query with every placeholder in phs
+// replaced by the result of repl. A space is written between a
+// replacement and the text next to it if they would otherwise run
+// together: 1-? with -1 would start a comment, and E? or ?'x' would
+// make the value part of a longer token. An error returned by repl is
+// wrapped in a *QueryError locating the placeholder.
+func replacePlaceholders(query string, phs []placeholder, repl func(ph placeholder) (string, error)) (string, error) {
+	return replacePlaceholdersLimit(query, phs, nil, repl)
+}
+
+// replacePlaceholdersLimit is like replacePlaceholders, but calls check,
+// if not nil, after each replacement with the length the output has
+// reached so far, counting the rest of query, and stops at the first
+// error it returns.
+func replacePlaceholdersLimit(query string, phs []placeholder, check func(n int) error, repl func(ph placeholder) (string, error)) (string, error) {
+	b := getBuffer()
+	defer putBuffer(b)
+	b.Grow(len(query))
+	last := 0
+	replaced := false
+	write := func(s string, separate bool) {
+		if separate && s != "" && b.Len() > 0 {
+			if joins(b.Bytes()[b.Len()-1], s[0]) {
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteString(s)
+	}
+	for _, ph := range phs {
+		s, err := repl(ph)
+		if err != nil {
+			return "", newQueryError(query, ph.start, err)
+		}
+		write(query[last:ph.start], replaced)
+		replaced = s != query[ph.start:ph.end]
+		write(s, replaced)
+		last = ph.end
+		if check != nil {
+			if err := check(b.Len() + len(query) - last); err != nil {
+				return "", err
+			}
+		}
+	}
+	write(query[last:], replaced)
+	if check != nil {
+		if err := check(b.Len()); err != nil {
+			return "", err
+		}
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,395 @@
+package informix
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestInterpolateQueryComments(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		args     []interface{}
+		expected string
+	}{
+		{
+			name:     "line comment",
+			query:    "SELECT * FROM t -- see ticket ?1234 and $1\nWHERE id = ?",
+			args:     []interface{}{7},
+			expected: "SELECT * FROM t -- see ticket ?1234 and $1\nWHERE id = 7",
+		},
+		{
+			name:     "line comment at end",
+			query:    "SELECT * FROM t WHERE id = $1 -- $2",
+			args:     []interface{}{7},
+			expected: "SELECT * FROM t WHERE id = 7 -- $2",
+		},
+		{
+			name:     "block comment",
+			query:    "SELECT /* $1 ? */ name FROM t WHERE id = $1",
+			args:     []interface{}{7},
+			expected: "SELECT /* $1 ? */ name FROM t WHERE id = 7",
+		},
+		{
+			name:     "multi-line block comment",
+			query:    "SELECT name /* first ?\n second $2 */ FROM t WHERE id = ?",
+			args:     []interface{}{7},
+			expected: "SELECT name /* first ?\n second $2 */ FROM t WHERE id = 7",
+		},
+		{
+			name:     "comment markers inside literal",
+			query:    "SELECT '-- not a comment', '/* nor this' FROM t WHERE a = ? AND b = ?",
+			args:     []interface{}{1, 2},
+			expected: "SELECT '-- not a comment', '/* nor this' FROM t WHERE a = 1 AND b = 2",
+		},
+		{
+			name:     "placeholders inside literal",
+			query:    "SELECT 'what?', 'it''s $1' FROM t WHERE a = $1",
+			args:     []interface{}{1},
+			expected: "SELECT 'what?', 'it''s $1' FROM t WHERE a = 1",
+		},
+		{
+			name:     "anonymous dollar quote",
+			query:    "CREATE FUNCTION f(int) RETURNS int AS $$ SELECT $1 + ? $$ LANGUAGE sql; SELECT f($1)",
+			args:     []interface{}{7},
+			expected: "CREATE FUNCTION f(int) RETURNS int AS $$ SELECT $1 + ? $$ LANGUAGE sql; SELECT f(7)",
+		},
+		{
+			name:     "tagged dollar quote",
+			query:    "SELECT $body$ it's $1 and $$ $body$, $1",
+			args:     []interface{}{7},
+			expected: "SELECT $body$ it's $1 and $$ $body$, 7",
+		},
+		{
+			name:     "dollar in identifier",
+			query:    "SELECT a$b$ FROM t WHERE id = $1",
+			args:     []interface{}{7},
+			expected: "SELECT a$b$ FROM t WHERE id = 7",
+		},
+		{
+			name:     "placeholder inside quoted identifier",
+			query:    `SELECT "odd?name" FROM t WHERE a = ?`,
+			args:     []interface{}{1},
+			expected: `SELECT "odd?name" FROM t WHERE a = 1`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := InterpolateQuery(tt.query, tt.args...)
+			if err != nil {
+				t.Fatalf("InterpolateQuery() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("InterpolateQuery() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestInterpolateQueryUnterminated(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		args     []interface{}
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:    "ends inside literal",
+			query:   "SELECT * FROM t WHERE a = ? AND b = 'open",
+			args:    []interface{}{1},
+			wantErr: true,
+		},
+		{
+			name:    "ends inside literal without arguments",
+			query:   "SELECT 'abc",
+			wantErr: true,
+		},
+		{
+			name:     "no arguments",
+			query:    "SELECT 'it''s'",
+			expected: "SELECT 'it''s'",
+		},
+		{
+			name:    "ends after escaped quote",
+			query:   "SELECT * FROM t WHERE a = ? AND b = 'it''",
+			args:    []interface{}{1},
+			wantErr: true,
+		},
+		{
+			name:    "ends inside quoted identifier",
+			query:   `SELECT "name FROM t WHERE a = ?`,
+			args:    []interface{}{1},
+			wantErr: true,
+		},
+		{
+			name:    "ends inside block comment",
+			query:   "SELECT * FROM t WHERE a = ? /* note",
+			args:    []interface{}{1},
+			wantErr: true,
+		},
+		{
+			name:    "ends inside dollar quote",
+			query:   "SELECT $tag$ $1 $$ WHERE a = $1",
+			args:    []interface{}{1},
+			wantErr: true,
+		},
+		{
+			name:     "escaped embedded quote",
+			query:    "SELECT * FROM t WHERE b = 'it''s ?' AND a = ?",
+			args:     []interface{}{1},
+			expected: "SELECT * FROM t WHERE b = 'it''s ?' AND a = 1",
+		},
+		{
+			name:     "literal at end",
+			query:    "SELECT * FROM t WHERE a = ? AND b = ''''",
+			args:     []interface{}{1},
+			expected: "SELECT * FROM t WHERE a = 1 AND b = ''''",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := InterpolateQuery(tt.query, tt.args...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("InterpolateQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnterminated) {
+					t.Errorf("InterpolateQuery() error = %v, want ErrUnterminated", err)
+				}
+				return
+			}
+			if got != tt.expected {
+				t.Errorf("InterpolateQuery() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestQueryError(t *testing.T) {
+	tests := []struct {
+		name    string
+		ip      *Interpolator
+		query   string
+		args    []interface{}
+		offset  int
+		line    int
+		column  int
+		wantErr error
+	}{
+		{
+			name:    "out of range placeholder",
+			ip:      &Interpolator{StrictArgs: true},
+			query:   "SELECT * FROM t WHERE a = $1 AND b = $9",
+			args:    []interface{}{1},
+			offset:  37,
+			line:    1,
+			column:  38,
+			wantErr: ErrTooFewArgs,
+		},
+		{
+			name:    "unterminated literal",
+			ip:      &Interpolator{},
+			query:   "SELECT *\nFROM t\nWHERE a = $1 AND b = 'open",
+			args:    []interface{}{1},
+			offset:  37,
+			line:    3,
+			column:  22,
+			wantErr: ErrUnterminated,
+		},
+		{
+			name:    "unsupported argument",
+			ip:      &Interpolator{},
+			query:   "SELECT *\n  FROM t WHERE a = ?",
+			args:    []interface{}{complex(1, 2)},
+			offset:  28,
+			line:    2,
+			column:  20,
+			wantErr: ErrUnsupportedType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.ip.InterpolateQuery(tt.query, tt.args...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("InterpolateQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var qe *QueryError
+			if !errors.As(err, &qe) {
+				t.Fatalf("InterpolateQuery() error = %v, want a *QueryError", err)
+			}
+			if qe.Offset != tt.offset || qe.Line != tt.line || qe.Column != tt.column {
+				t.Errorf("QueryError at offset %d, line %d, column %d, want offset %d, line %d, column %d",
+					qe.Offset, qe.Line, qe.Column, tt.offset, tt.line, tt.column)
+			}
+		})
+	}
+
+	_, _, err := Parameterize("SELECT * FROM t WHERE a = $1 AND b = $9", 1)
+	var qe *QueryError
+	if !errors.As(err, &qe) || qe.Offset != 37 {
+		t.Errorf("Parameterize() error = %v, want a *QueryError at offset 37", err)
+	}
+}
+
+func TestInterpolateQueryAdjacentTokens(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		args     []interface{}
+		expected string
+	}{
+		{
+			name:     "negative number after minus",
+			query:    "SELECT 1-? FROM t",
+			args:     []interface{}{-1},
+			expected: "SELECT 1- -1 FROM t",
+		},
+		{
+			name:     "string after letter",
+			query:    `SELECT E?`,
+			args:     []interface{}{`\x27`},
+			expected: `SELECT E '\x27'`,
+		},
+		{
+			name:     "string before literal",
+			query:    "SELECT ?'x'",
+			args:     []interface{}{"y"},
+			expected: "SELECT 'y' 'x'",
+		},
+		{
+			name:     "adjacent placeholders",
+			query:    "SELECT ??",
+			args:     []interface{}{"a", "b"},
+			expected: "SELECT 'a' 'b'",
+		},
+		{
+			name:     "number before decimal point",
+			query:    "SELECT ?.5",
+			args:     []interface{}{1},
+			expected: "SELECT 1 .5",
+		},
+		{
+			name:     "ordinary punctuation",
+			query:    "SELECT (?),?::int",
+			args:     []interface{}{1, "2"},
+			expected: "SELECT (1),'2'::int",
+		},
+		{
+			name:     "dollar number followed by identifier",
+			query:    "SELECT $1abc, $1",
+			args:     []interface{}{7},
+			expected: "SELECT $1abc, 7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := InterpolateQuery(tt.query, tt.args...)
+			if err != nil {
+				t.Fatalf("InterpolateQuery() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("InterpolateQuery() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestInterpolateQueryStyle(t *testing.T) {
+	tests := []struct {
+		name     string
+		style    Style
+		query    string
+		args     []interface{}
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "colon numeric",
+			style:    StyleColonNumeric,
+			query:    "SELECT * FROM t WHERE a = :1 AND b = :2 OR c = :1",
+			args:     []interface{}{1, "x"},
+			expected: "SELECT * FROM t WHERE a = 1 AND b = 'x' OR c = 1",
+		},
+		{
+			name:     "colon numeric with cast",
+			style:    StyleColonNumeric,
+			query:    "SELECT :1::int, a::text FROM t WHERE b = :2",
+			args:     []interface{}{"7", "x"},
+			expected: "SELECT '7'::int, a::text FROM t WHERE b = 'x'",
+		},
+		{
+			name:     "colon numeric ignores other styles",
+			style:    StyleColonNumeric,
+			query:    "SELECT ?, $1, :name, arr[1:2] FROM t WHERE a = :1",
+			args:     []interface{}{1},
+			expected: "SELECT ?, $1, :name, arr[1:2] FROM t WHERE a = 1",
+		},
+		{
+			name:     "colon numeric in literal",
+			style:    StyleColonNumeric,
+			query:    "SELECT ':1' FROM t WHERE a = :1",
+			args:     []interface{}{1},
+			expected: "SELECT ':1' FROM t WHERE a = 1",
+		},
+		{
+			name:    "colon numeric too large",
+			style:   StyleColonNumeric,
+			query:   "SELECT * FROM t WHERE a = :1 AND b = :18446744073709551617",
+			args:    []interface{}{1},
+			wantErr: true,
+		},
+		{
+			name:     "colon numeric too large in token",
+			style:    StyleColonNumeric,
+			query:    "SELECT a:99999999999999999999x FROM t WHERE a = :1",
+			args:     []interface{}{1},
+			expected: "SELECT a:99999999999999999999x FROM t WHERE a = 1",
+		},
+		{
+			name:     "dollar too large in token",
+			style:    StyleDollar,
+			query:    "SELECT $99999999999999999999abc FROM t WHERE a = $1",
+			args:     []interface{}{1},
+			expected: "SELECT $99999999999999999999abc FROM t WHERE a = 1",
+		},
+		{
+			name:     "question only",
+			style:    StyleQuestion,
+			query:    "SELECT '$1', $1 FROM t WHERE a = ?",
+			args:     []interface{}{1},
+			expected: "SELECT '$1', $1 FROM t WHERE a = 1",
+		},
+		{
+			name:     "dollar only",
+			style:    StyleDollar,
+			query:    "SELECT a ? b FROM t WHERE a = $1",
+			args:     []interface{}{1},
+			expected: "SELECT a ? b FROM t WHERE a = 1",
+		},
+		{
+			name:    "named",
+			style:   StyleNamed,
+			query:   "SELECT * FROM t WHERE a = :a",
+			args:    []interface{}{1},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip := &Interpolator{Style: tt.style}
+			got, err := ip.InterpolateQuery(tt.query, tt.args...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("InterpolateQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("InterpolateQuery() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,6 @@
+package informix
+
+// Serial is an argument for a SERIAL, SERIAL8 or BIGSERIAL column that
+// asks Informix to assign the next value. It is written as 0, which the
+// server replaces with the generated value.
+type Serial struct{}
@@ -0,0 +1,18 @@
+package informix
+
+import "testing"
+
+func TestSerial(t *testing.T) {
+	got, err := InterpolateQuery("INSERT INTO orders (id, item) VALUES (?, ?)", Serial{}, "book")
+	if err != nil {
+		t.Fatalf("InterpolateQuery() error = %v", err)
+	}
+	if want := "INSERT INTO orders (id, item) VALUES (0, 'book')"; got != want {
+		t.Errorf("InterpolateQuery() = %v, want %v", got, want)
+	}
+
+	ip := &Interpolator{Dialect: Informix}
+	if got, err := ip.SanitizeValue(&Serial{}); err != nil || got != "0" {
+		t.Errorf("SanitizeValue() = %v, %v, want 0", got, err)
+	}
+}
@@ -0,0 +1,66 @@
+package informix
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Tuples is a list of row values for a row-value IN predicate, as in
+// WHERE (a, b) IN $1. It is rendered as ((1,'x'),(2,'y')). Every tuple
+// must have the same, non-zero number of values, and each value is
+// formatted like any other argument. Since no list of tuples can be
+// empty in SQL, an empty Tuples is an error wrapping ErrEmptySlice,
+// whatever the EmptySlice option.
+type Tuples [][]interface{}
+
+// formatTuples returns t as a parenthesized list of tuples.
+func (ip *Interpolator) formatTuples(t Tuples) (string, error) {
+	if err := t.check(); err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	b.Grow(2 + len(t)*(3+len(t[0])*elementSizeHint))
+	b.WriteByte('(')
+	for i, tuple := range t {
+		if err := ip.checkContext(i); err != nil {
+			return "", err
+		}
+		if err := ip.checkSize(b.Len()); err != nil {
+			return "", err
+		}
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteByte('(')
+		for j, v := range tuple {
+			s, err := ip.formatArgument(v)
+			if err != nil {
+				return "", fmt.Errorf("tuple %d, value %d: %w", i, j, err)
+			}
+			if j > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(s)
+		}
+		b.WriteByte(')')
+	}
+	b.WriteByte(')')
+	return b.String(), nil
+}
+
+// check returns an error unless t has at least one tuple and all its
+// tuples have the same, non-zero number of values.
+func (t Tuples) check() error {
+	if len(t) == 0 {
+		return fmt.Errorf("%w: no tuples", ErrEmptySlice)
+	}
+	for i, tuple := range t {
+		if len(tuple) == 0 {
+			return fmt.Errorf("tuple %d is empty", i)
+		}
+		if len(tuple) != len(t[0]) {
+			return fmt.Errorf("tuple %d has %d values, want %d", i, len(tuple), len(t[0]))
+		}
+	}
+	return nil
+}
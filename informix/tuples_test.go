@@ -0,0 +1,77 @@
+package informix
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTuples(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  Dialect
+		input    Tuples
+		expected string
+		wantErr  error
+	}{
+		{
+			name:     "two columns",
+			input:    Tuples{{1, "a"}, {2, "b"}},
+			expected: "((1,'a'),(2,'b'))",
+		},
+		{
+			name:     "three columns",
+			input:    Tuples{{1, "it's", nil}, {2, "b", true}, {3, "c", false}},
+			expected: "((1,'it''s',NULL),(2,'b',true),(3,'c',false))",
+		},
+		{
+			name:     "informix three columns",
+			dialect:  Informix,
+			input:    Tuples{{1, 2, true}, {4, 5, false}},
+			expected: "((1,2,'t'),(4,5,'f'))",
+		},
+		{
+			name:     "single tuple",
+			input:    Tuples{{1, 2}},
+			expected: "((1,2))",
+		},
+		{
+			name:    "different lengths",
+			input:   Tuples{{1, 2}, {3}},
+			wantErr: errAny,
+		},
+		{
+			name:    "empty tuple",
+			input:   Tuples{{}},
+			wantErr: errAny,
+		},
+		{
+			name:    "no tuples",
+			input:   Tuples{},
+			wantErr: ErrEmptySlice,
+		},
+		{
+			name:    "unsupported value",
+			input:   Tuples{{1, complex(1, 2)}},
+			wantErr: ErrUnsupportedType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip := &Interpolator{Dialect: tt.dialect}
+			got, err := ip.InterpolateQuery("SELECT * FROM t WHERE (a, b) IN $1", tt.input)
+			switch {
+			case tt.wantErr == errAny && err == nil:
+				t.Fatalf("InterpolateQuery() error = nil, want an error")
+			case tt.wantErr != errAny && !errors.Is(err, tt.wantErr):
+				t.Fatalf("InterpolateQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if want := "SELECT * FROM t WHERE (a, b) IN " + tt.expected; got != want {
+				t.Errorf("InterpolateQuery() = %v, want %v", got, want)
+			}
+		})
+	}
+}
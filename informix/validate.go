@@ -0,0 +1,135 @@
+package informix
+
+import "fmt"
+
+// PlaceholderError describes a problem with the placeholders of a
+// query.
+type PlaceholderError struct {
+	// Num is the number of the offending $n placeholder, or 0 when
+	// the problem is not tied to one number.
+	Num int
+
+	// Reason describes the problem.
+	Reason string
+}
+
+func (e *PlaceholderError) Error() string {
+	if e.Num == 0 {
+		return e.Reason
+	}
+	return fmt.Sprintf("placeholder $%d: %s", e.Num, e.Reason)
+}
+
+// ValidateQuery checks the placeholders of query without interpolating
+// it, so that queries can be checked when a program starts. It returns
+// a *PlaceholderError if the query mixes ? and $n placeholders, uses
+// $0 or a number above 65535, or skips a number, as in $1 and $3
+// without $2. Repeating a $n placeholder is allowed.
+func ValidateQuery(query string) error {
+	return defaultInterpolator().ValidateQuery(query)
+}
+
+// ValidateQuery is like the package level ValidateQuery, but numbers
+// placeholders from ip.PlaceholderBase.
+func (ip *Interpolator) ValidateQuery(query string) error {
+	phs, err := ip.scanPlaceholders(query)
+	if err != nil {
+		return err
+	}
+	return validatePlaceholders(phs, ip.dollarBase())
+}
+
+// PlaceholderCount returns the number of arguments query expects: the
+// number of ? placeholders, or the highest n of its $n placeholders.
+// Placeholders in literals and comments are not counted. It returns
+// the same errors as ValidateQuery.
+func PlaceholderCount(query string) (int, error) {
+	return defaultInterpolator().PlaceholderCount(query)
+}
+
+// PlaceholderCount is like the package level PlaceholderCount, but
+// numbers placeholders from ip.PlaceholderBase.
+func (ip *Interpolator) PlaceholderCount(query string) (int, error) {
+	phs, err := ip.scanPlaceholders(query)
+	if err != nil {
+		return 0, err
+	}
+	if err := validatePlaceholders(phs, ip.dollarBase()); err != nil {
+		return 0, err
+	}
+	return assignIndexes(phs, ip.dollarBase()), nil
+}
+
+// CheckArgs checks that every argument in args can be formatted as a
+// SQL literal, without building a query, so that a caller can fail
+// fast before interpolating. It formats each argument, discarding the
+// result, and returns the first error, naming the argument. Arguments,
+// or slice elements, of a type with no SQL form, such as a complex
+// number, a channel or a function, and structs or maps that cannot be
+// stored as JSON give an error wrapping ErrUnsupportedType.
+func CheckArgs(args ...interface{}) error {
+	return defaultInterpolator().CheckArgs(args...)
+}
+
+// CheckArgs is like the package level CheckArgs, but uses the settings
+// of ip.
+func (ip *Interpolator) CheckArgs(args ...interface{}) error {
+	for i, arg := range args {
+		if _, err := ip.formatArgument(arg); err != nil {
+			return fmt.Errorf("argument %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// validatePlaceholders implements ValidateQuery for the placeholders
+// of a query whose numbering starts at $base.
+func validatePlaceholders(phs []placeholder, base int) error {
+	if err := checkMixedStyles(phs); err != nil {
+		return err
+	}
+	seen := make(map[int]bool)
+	max := 0
+	for _, ph := range phs {
+		if ph.kind == dollarNumber {
+			if ph.num < base {
+				return &PlaceholderError{Num: ph.num, Reason: fmt.Sprintf("numbering starts at $%d", base)}
+			}
+			seen[ph.num] = true
+			if ph.num > max {
+				max = ph.num
+			}
+		}
+	}
+	for n := base; n < max; n++ {
+		if !seen[n] {
+			if n == 0 {
+				return &PlaceholderError{Reason: fmt.Sprintf("placeholder $0 missing, but $%d is used", max)}
+			}
+			return &PlaceholderError{Num: n, Reason: fmt.Sprintf("missing, but $%d is used", max)}
+		}
+	}
+	return nil
+}
+
+// errMixedStyles is returned for queries that use both ? and $n
+// placeholders.
+var errMixedStyles = &PlaceholderError{Reason: "query mixes ? and $n placeholders"}
+
+// checkMixedStyles returns errMixedStyles if phs contains both ? and $n
+// placeholders.
+func checkMixedStyles(phs []placeholder) error {
+	var question, dollar bool
+	for _, ph := range phs {
+		switch ph.kind {
+		case questionMark:
+			question = true
+		case dollarNumber:
+			dollar = true
+		}
+	}
+	if question && dollar {
+		return errMixedStyles
+	}
+	return nil
+}
@@ -0,0 +1,285 @@
+package informix
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		base    int
+		query   string
+		wantErr bool
+		wantNum int
+	}{
+		{
+			name:  "sequential",
+			query: "SELECT * FROM t WHERE a = $1 AND b = $2",
+		},
+		{
+			name:  "question marks",
+			query: "SELECT * FROM t WHERE a = ? AND b = ?",
+		},
+		{
+			name:  "duplicates without gaps",
+			query: "SELECT * FROM t WHERE a = $2 OR b = $1 OR c = $2",
+		},
+		{
+			name:  "no placeholders",
+			query: "SELECT * FROM t",
+		},
+		{
+			name:  "placeholder in literal",
+			query: "SELECT * FROM t WHERE a = $1 AND b = '$3'",
+		},
+		{
+			name:    "gap",
+			query:   "SELECT * FROM t WHERE a = $1 AND b = $3",
+			wantErr: true,
+			wantNum: 2,
+		},
+		{
+			name:    "duplicates with gap",
+			query:   "SELECT * FROM t WHERE a = $1 OR b = $1 OR c = $4 OR d = $2",
+			wantErr: true,
+			wantNum: 3,
+		},
+		{
+			name:    "zero",
+			query:   "SELECT * FROM t WHERE a = $0",
+			wantErr: true,
+			wantNum: 0,
+		},
+		{
+			name:  "zero based",
+			base:  ZeroBase,
+			query: "SELECT * FROM t WHERE a = $0 AND b = $1",
+		},
+		{
+			name:    "zero based gap",
+			base:    ZeroBase,
+			query:   "SELECT * FROM t WHERE a = $0 AND b = $2",
+			wantErr: true,
+			wantNum: 1,
+		},
+		{
+			name:    "zero based missing zero",
+			base:    ZeroBase,
+			query:   "SELECT * FROM t WHERE a = $1",
+			wantErr: true,
+			wantNum: 0,
+		},
+		{
+			name:  "custom base",
+			base:  5,
+			query: "SELECT * FROM t WHERE a = $5 AND b = $6",
+		},
+		{
+			name:    "below custom base",
+			base:    5,
+			query:   "SELECT * FROM t WHERE a = $4 AND b = $5",
+			wantErr: true,
+			wantNum: 4,
+		},
+		{
+			name:    "custom base gap",
+			base:    5,
+			query:   "SELECT * FROM t WHERE a = $5 AND b = $7",
+			wantErr: true,
+			wantNum: 6,
+		},
+		{
+			name:    "number wraps around",
+			query:   "SELECT * FROM t WHERE a = $1 AND b = $18446744073709551617",
+			wantErr: true,
+			wantNum: 0,
+		},
+		{
+			name:    "number too large",
+			query:   "SELECT * FROM t WHERE a = $65536",
+			wantErr: true,
+			wantNum: 0,
+		},
+		{
+			name:    "mixed styles",
+			query:   "SELECT * FROM t WHERE a = ? AND b = $1",
+			wantErr: true,
+			wantNum: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip := &Interpolator{PlaceholderBase: tt.base}
+			err := ip.ValidateQuery(tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				return
+			}
+			var perr *PlaceholderError
+			if !errors.As(err, &perr) {
+				t.Fatalf("ValidateQuery() error = %T, want *PlaceholderError", err)
+			}
+			if perr.Num != tt.wantNum {
+				t.Errorf("PlaceholderError.Num = %d, want %d", perr.Num, tt.wantNum)
+			}
+		})
+	}
+}
+
+func TestPlaceholderCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     int
+		query    string
+		expected int
+		wantErr  bool
+	}{
+		{
+			name:     "question marks",
+			query:    "SELECT * FROM t WHERE a = ? AND b = ? AND c = ?",
+			expected: 3,
+		},
+		{
+			name:     "dollar numbers",
+			query:    "SELECT * FROM t WHERE a = $2 AND b = $1 OR c = $2",
+			expected: 2,
+		},
+		{
+			name:    "dollar numbers with gap",
+			query:   "SELECT * FROM t WHERE a = $1 AND b = $3",
+			wantErr: true,
+		},
+		{
+			name:     "placeholders in literals and comments",
+			query:    "SELECT '?', '$2' FROM t /* ? */ WHERE a = ? -- ?",
+			expected: 1,
+		},
+		{
+			name:     "none",
+			query:    "SELECT * FROM t",
+			expected: 0,
+		},
+		{
+			name:     "zero based",
+			base:     ZeroBase,
+			query:    "SELECT * FROM t WHERE a = $0 AND b = $1",
+			expected: 2,
+		},
+		{
+			name:     "custom base",
+			base:     5,
+			query:    "SELECT * FROM t WHERE a = $5 AND b = $6 OR c = $5",
+			expected: 2,
+		},
+		{
+			name:    "zero without zero based",
+			query:   "SELECT * FROM t WHERE a = $0",
+			wantErr: true,
+		},
+		{
+			name:    "mixed styles",
+			query:   "SELECT * FROM t WHERE a = ? AND b = $1",
+			wantErr: true,
+		},
+		{
+			name:    "unterminated literal",
+			query:   "SELECT * FROM t WHERE a = ? AND b = '",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip := &Interpolator{PlaceholderBase: tt.base}
+			got, err := ip.PlaceholderCount(tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PlaceholderCount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("PlaceholderCount() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCheckArgs(t *testing.T) {
+	type status string
+	n := 1
+
+	tests := []struct {
+		name    string
+		args    []interface{}
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			args: []interface{}{1, "a", nil, &n, status("x"), []int{1, 2}, map[string]int{"a": 1}, struct{ A int }{1}, Current},
+		},
+		{
+			name:    "channel",
+			args:    []interface{}{1, make(chan int)},
+			wantErr: true,
+		},
+		{
+			name:    "func",
+			args:    []interface{}{func() {}},
+			wantErr: true,
+		},
+		{
+			name:    "complex",
+			args:    []interface{}{complex(1, 2)},
+			wantErr: true,
+		},
+		{
+			name:    "pointer to slice of funcs",
+			args:    []interface{}{&[]func(){func() {}}},
+			wantErr: true,
+		},
+		{
+			name:    "slice element",
+			args:    []interface{}{[]interface{}{1, make(chan int)}},
+			wantErr: true,
+		},
+		{
+			name:    "struct that is not JSON",
+			args:    []interface{}{struct{ C chan int }{}},
+			wantErr: true,
+		},
+		{
+			name:    "tuple value",
+			args:    []interface{}{Tuples{{1, func() {}}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckArgs(tt.args...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckArgs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, ErrUnsupportedType) {
+				t.Errorf("CheckArgs() error = %v, want %v", err, ErrUnsupportedType)
+			}
+			// CheckArgs must agree with formatting.
+			for _, arg := range tt.args {
+				if _, err := formatArgument(arg); err != nil && !tt.wantErr {
+					t.Errorf("formatArgument(%T) error = %v after CheckArgs passed", arg, err)
+				}
+			}
+		})
+	}
+
+	if err := CheckArgs(cyclicValuer{}); err == nil {
+		t.Errorf("CheckArgs() with a cyclic driver.Valuer did not fail")
+	}
+
+	err := CheckArgs(1, "a", make(chan int))
+	if err == nil || err.Error() != "argument 3: unsupported argument type: chan int" {
+		t.Errorf("CheckArgs() error = %v, want it to name argument 3 and its type", err)
+	}
+}